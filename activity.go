@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// activityExpiryDefault is used when the game server does not report when the activity data expires.
+const activityExpiryDefault = time.Hour
+
+// activityHeaders are the headers of the optional activity columns in the solar system table.
+var activityHeaders = []string{"ShipKills", "PodKills", "NPCKills", "Jumps"}
+
+// activityValues returns the values of the activity columns for a solar system.
+func activityValues(o EveSolarSystemActivity) []any {
+	return []any{o.ShipKills, o.PodKills, o.NPCKills, o.Jumps}
+}
+
+// fetchActivity returns the recent kills and jumps of solar systems by their ID.
+func (a App) fetchActivity(solarSystemIDs []int32) (map[int32]EveSolarSystemActivity, error) {
+	objs, missing, err := a.st.ListFreshEveSolarSystemActivityByID(solarSystemIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		objs, err = a.refreshActivity(missing)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return makeLookupMap(objs), nil
+}
+
+// refreshActivity fetches the kills and jumps of all solar systems from ESI, stores them and returns them.
+// Additional solar systems without any activity are stored too.
+// The activity is kept until the earliest expiry reported by the game server.
+func (a App) refreshActivity(solarSystemIDs []int32) ([]EveSolarSystemActivity, error) {
+	activity := make(map[int32]EveSolarSystemActivity)
+	var mu sync.Mutex
+	expires := now().Add(activityExpiryDefault)
+	updateExpires := func(r *http.Response) {
+		expires = responseExpires(r, expires)
+	}
+	g := new(errgroup.Group)
+	g.Go(func() error {
+		data, r, err := a.esiClient.ESI.UniverseApi.GetUniverseSystemKills(context.Background(), nil)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		updateExpires(r)
+		for _, x := range data {
+			o := activity[x.SystemId]
+			o.NPCKills = x.NpcKills
+			o.PodKills = x.PodKills
+			o.ShipKills = x.ShipKills
+			activity[x.SystemId] = o
+		}
+		return nil
+	})
+	g.Go(func() error {
+		data, r, err := a.esiClient.ESI.UniverseApi.GetUniverseSystemJumps(context.Background(), nil)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		updateExpires(r)
+		for _, x := range data {
+			o := activity[x.SystemId]
+			o.Jumps = x.ShipJumps
+			activity[x.SystemId] = o
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	for _, id := range solarSystemIDs {
+		if _, ok := activity[id]; !ok {
+			activity[id] = EveSolarSystemActivity{}
+		}
+	}
+	objs := make([]EveSolarSystemActivity, 0, len(activity))
+	for id, o := range activity {
+		o.Expires = expires
+		o.SolarSystemID = id
+		o.Timestamp = now()
+		objs = append(objs, o)
+	}
+	if err := a.st.UpdateOrCreateEveSolarSystemActivity(objs); err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
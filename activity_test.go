@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_RunActivity(t *testing.T) {
+	entities := []entity{
+		{30002537, "Amamake", "solar_system"},
+		{30002538, "Vard", "solar_system"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint(entities),
+	)
+	var expires time.Time
+	makeResponder := func(data []map[string]any) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			r, err := httpmock.NewJsonResponse(200, data)
+			if err != nil {
+				return nil, err
+			}
+			r.Header.Set("Expires", expires.Format(http.TimeFormat))
+			return r, nil
+		}
+	}
+	killsURL := `=~^https://esi\.evetech\.net/v\d+/universe/system_kills/`
+	httpmock.RegisterResponder(
+		"GET",
+		killsURL,
+		makeResponder([]map[string]any{
+			{"system_id": 30002537, "ship_kills": 12, "pod_kills": 40, "npc_kills": 3},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/system_jumps/`,
+		makeResponder([]map[string]any{
+			{"system_id": 30002537, "ship_jumps": 250},
+			{"system_id": 30002538, "ship_jumps": 80},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/sovereignty/(map|structures)/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/systems/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			30002537: {"constellation_id": 20000372, "name": "Amamake", "security_status": 0.4},
+			30002538: {"constellation_id": 20000372, "name": "Vard", "security_status": 0.4},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/constellations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			20000372: {"name": "Hed", "region_id": 10000042},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/regions/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			10000042: {"name": "Metropolis"},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show kills and jumps of solar systems", func(t *testing.T) {
+		st.MustClear()
+		expires = time.Now().Add(30 * time.Minute)
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Activity = true
+		a.Columns = []string{"name", "shipkills", "podkills", "npckills", "jumps"}
+		err := a.Run([]string{"30002537", "30002538"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Amamake\s+│ 12\s+│ 40\s+│ 3\s+│ 250`, got)
+		assert.Regexp(t, `Vard\s+│ 0\s+│ 0\s+│ 0\s+│ 80`, got)
+	})
+	t.Run("should not show activity by default", func(t *testing.T) {
+		st.MustClear()
+		expires = time.Now().Add(30 * time.Minute)
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.Run([]string{"30002537"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.NotRegexp(t, `(?i)pod ?kills`, buf.String())
+	})
+	t.Run("should use cached activity until it expires", func(t *testing.T) {
+		st.MustClear()
+		expires = time.Now().Add(30 * time.Minute)
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		a.Activity = true
+		err := a.Run([]string{"30002537"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		n := httpmock.GetCallCountInfo()["GET "+killsURL]
+		err = a.Run([]string{"30002537", "30002538"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Equal(t, n, httpmock.GetCallCountInfo()["GET "+killsURL])
+	})
+	t.Run("should fetch activity again after it has expired", func(t *testing.T) {
+		st.MustClear()
+		expires = time.Now().Add(-time.Minute)
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		a.Activity = true
+		err := a.Run([]string{"30002537"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		n := httpmock.GetCallCountInfo()["GET "+killsURL]
+		err = a.Run([]string{"30002537"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Equal(t, n+1, httpmock.GetCallCountInfo()["GET "+killsURL])
+	})
+}
@@ -5,30 +5,45 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
 	"io"
 	"log/slog"
 	"maps"
 	"net/http"
+	"regexp"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/antihax/goesi"
 	"github.com/antihax/goesi/esi"
-	"github.com/olekukonko/tablewriter"
-	"github.com/olekukonko/tablewriter/renderer"
-	"github.com/olekukonko/tablewriter/tw"
 	"github.com/schollz/progressbar/v3"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
 )
 
 const (
 	nameInvalid = "INVALID"
 )
 
+// maxConcurrentRequests is the maximum number of concurrent requests to the game server
+// when fetching many objects, which keeps clear of its error limits.
+const maxConcurrentRequests = 20
+
+// ANSI escape codes for terminal colors
+const (
+	colorBlue   = "\033[34m"
+	colorGreen  = "\033[32m"
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorReset  = "\033[0m"
+)
+
 type result struct {
-	category EveEntityCategory
-	table    *tablewriter.Table
+	title string
+	table *table
 }
 
 type App struct {
@@ -41,8 +56,45 @@ type App struct {
 	// Max width of the terminal in characters.
 	MaxWidth int
 
+	// Whether to show each object as card with all details
+	Detail bool
+
+	// When specified show only these columns in this order
+	Columns []string
+
+	// When specified sort the rows by this column. Descending when starting with "-".
+	Sort string
+
+	// Whether to show a summary of characters grouped by alliance and corporation
+	Summary bool
+
+	// Whether to also list the constellations and solar systems of regions and constellations
+	Expand bool
+
+	// Whether to show the recent kills and jumps of solar systems
+	Activity bool
+
+	// Whether to show the market prices of inventory types
+	Prices bool
+
+	// When specified show the best buy and sell prices of inventory types at this trade hub or region
+	Market string
+
+	// Security preference for routes: shortest, secure or insecure
+	RoutePreference string
+
+	// Solar systems to avoid for routes
+	RouteAvoid []string
+
+	// Maximum number of jumps for the range of a solar system
+	RangeJumps int
+
+	// Rows matching entities on the watchlist are highlighted by their standing
+	Watchlist []WatchlistEntry
+
 	esiClient *goesi.APIClient
 	out       io.Writer
+	standings map[int32]float64 // standings from the resolved watchlist by entity ID
 	st        *Storage
 }
 
@@ -57,73 +109,43 @@ func NewApp(esiClient *goesi.APIClient, st *Storage, out io.Writer) App {
 
 // Run is the main entry point.
 func (a App) Run(args []string) error {
-	// Parse args
-	var (
-		ids     []int32
-		invalid []int
-		names   []string
-	)
-	for _, arg := range args {
-		id, err := strconv.Atoi(arg)
-		if err != nil {
-			names = append(names, arg)
-		} else {
-			id32 := int32(id)
-			if int(id32) != id || id == 0 {
-				invalid = append(invalid, id)
-				continue
-			}
-			ids = append(ids, id32)
-		}
-	}
-	if len(invalid) > 0 {
-		fmt.Fprintf(a.out, "Ignoring invalid IDs: %v\n", invalid)
-	}
-
-	count := len(ids) + len(names)
-	if count == 0 {
-		return fmt.Errorf("no suitable input to process")
+	ids, names, err := a.parseValues(args)
+	if err != nil {
+		return err
 	}
 
 	// Resolve ids and names
-	var bar *progressbar.ProgressBar
-	if !a.SpinnerDisabled {
-		bar = progressbar.NewOptions(-1,
-			progressbar.OptionSpinnerType(14), // choose spinner style (0–39)
-			progressbar.OptionSetDescription(fmt.Sprintf("Resolving %d IDs/names ...", count)),
-			progressbar.OptionSetRenderBlankState(true),
-			progressbar.OptionSetWriter(a.out),
-		)
-	}
-	g := new(errgroup.Group)
-	var entities1, entities2 []EveEntity
-	if len(ids) > 0 {
-		g.Go(func() error {
-			oo, err := a.resolveIDs(ids)
-			if err != nil {
-				return err
-			}
-			entities1 = oo
-			return nil
-		})
-	}
-	if len(names) > 0 {
-		g.Go(func() error {
-			oo, err := a.resolveNames(names)
-			if err != nil {
-				return err
-			}
-			entities2 = oo
-			return nil
-		})
+	standings, err := a.resolveWatchlist()
+	if err != nil {
+		return err
 	}
-	if err := g.Wait(); err != nil {
+	a.standings = standings
+	bar := a.newSpinner(fmt.Sprintf("Resolving %d IDs/names ...", len(ids)+len(names)))
+	entities, err := a.resolveValues(ids, names)
+	if err != nil {
 		return err
 	}
-	entities := slices.Concat(entities1, entities2)
 
 	slog.Info("resolved entities from input values", "count", len(entities))
 
+	if a.Summary {
+		var characterIDs []int32
+		for _, e := range entities {
+			if e.Category == CategoryCharacter {
+				characterIDs = append(characterIDs, e.ID())
+			}
+		}
+		results, err := a.buildCharacterSummary(characterIDs)
+		if err != nil {
+			return err
+		}
+		if bar != nil {
+			bar.Clear()
+		}
+		fmt.Fprintf(a.out, "Summary of %d characters\n", len(characterIDs))
+		return a.printResults(results)
+	}
+
 	// build results
 	category2IDs := make(map[EveEntityCategory][]int32)
 	for _, e := range entities {
@@ -133,6 +155,7 @@ func (a App) Run(args []string) error {
 		category2IDs[e.Category] = append(category2IDs[e.Category], e.ID())
 	}
 	results := make([]result, len(category2IDs))
+	expanded := make([][]result, len(category2IDs))
 	if len(results) == 0 {
 		if bar != nil {
 			bar.Clear()
@@ -150,61 +173,99 @@ func (a App) Run(args []string) error {
 				if err != nil {
 					return err
 				}
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
 			case CategoryAlliance:
 				t, err := a.buildAllianceTable(ids)
 				if err != nil {
 					return err
 				}
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
+			case CategoryAsteroidBelt:
+				t, err := a.buildAsteroidBeltTable(ids)
+				if err != nil {
+					return err
+				}
+				results[i] = result{c.Display(), t}
 			case CategoryCharacter:
 				t, err := a.buildCharacterTable(ids)
 				if err != nil {
 					return err
 				}
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
 			case CategoryConstellation:
 				t, err := a.buildConstellationTable(ids)
 				if err != nil {
 					return err
 				}
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
+				if a.Expand {
+					rr, err := a.expandConstellations(ids)
+					if err != nil {
+						return err
+					}
+					expanded[i] = rr
+				}
 			case CategoryCorporation:
 				t, err := a.buildCorporationTable(ids)
 				if err != nil {
 					return err
 				}
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
 			case CategoryFaction:
 				t, err := a.buildFactionTable(ids)
 				if err != nil {
 					return err
 				}
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
 			case CategoryInventoryType:
 				t, err := a.buildTypeTable(ids)
 				if err != nil {
 					return err
 				}
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
+			case CategoryMoon:
+				t, err := a.buildMoonTable(ids)
+				if err != nil {
+					return err
+				}
+				results[i] = result{c.Display(), t}
+			case CategoryPlanet:
+				t, err := a.buildPlanetTable(ids)
+				if err != nil {
+					return err
+				}
+				results[i] = result{c.Display(), t}
 			case CategoryRegion:
 				t, err := a.buildRegionTable(ids)
 				if err != nil {
 					return err
 				}
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
+				if a.Expand {
+					rr, err := a.expandRegions(ids)
+					if err != nil {
+						return err
+					}
+					expanded[i] = rr
+				}
 			case CategorySolarSystem:
 				t, err := a.buildSolarSystemTable(ids)
 				if err != nil {
 					return err
 				}
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
+			case CategoryStargate:
+				t, err := a.buildStargateTable(ids)
+				if err != nil {
+					return err
+				}
+				results[i] = result{c.Display(), t}
 			case CategoryStation:
 				t, err := a.buildStationTable(ids)
 				if err != nil {
 					return err
 				}
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
 			case CategoryInvalid:
 				entities2 := slices.DeleteFunc(entities, func(o EveEntity) bool {
 					return o.Category != CategoryInvalid
@@ -216,7 +277,7 @@ func (a App) Run(args []string) error {
 						return []any{o.EntityID, o.Name, o.Category.Display()}
 					},
 				)
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
 			default:
 				entities, _, err := a.st.ListFreshEveEntityByID(ids)
 				if err != nil {
@@ -230,7 +291,7 @@ func (a App) Run(args []string) error {
 						return []any{o.EntityID, o.Name, o.Category.Display()}
 					},
 				)
-				results[i] = result{c, t}
+				results[i] = result{c.Display(), t}
 			}
 			slog.Info("Resolved objects", "category", c, "count", len(ids))
 			return nil
@@ -244,13 +305,110 @@ func (a App) Run(args []string) error {
 		bar.Clear()
 	}
 
-	// Print results
+	var results2 []result
+	for i, r := range results {
+		results2 = append(results2, r)
+		results2 = append(results2, expanded[i]...)
+	}
+	return a.printResults(results2)
+}
+
+// parseValues returns the IDs and names from values given as input.
+// Invalid IDs are reported and ignored.
+func (a App) parseValues(values []string) ([]int32, []string, error) {
+	var (
+		ids     []int32
+		invalid []int
+		names   []string
+	)
+	for _, arg := range values {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			names = append(names, arg)
+		} else {
+			id32 := int32(id)
+			if int(id32) != id || id == 0 {
+				invalid = append(invalid, id)
+				continue
+			}
+			ids = append(ids, id32)
+		}
+	}
+	if len(invalid) > 0 {
+		fmt.Fprintf(a.out, "Ignoring invalid IDs: %v\n", invalid)
+	}
+	if len(ids)+len(names) == 0 {
+		return nil, nil, fmt.Errorf("no suitable input to process")
+	}
+	return ids, names, nil
+}
+
+// resolveValues resolves IDs and names concurrently and returns the entities.
+func (a App) resolveValues(ids []int32, names []string) ([]EveEntity, error) {
+	g := new(errgroup.Group)
+	var entities1, entities2 []EveEntity
+	if len(ids) > 0 {
+		g.Go(func() error {
+			oo, err := a.resolveIDs(ids)
+			if err != nil {
+				return err
+			}
+			entities1 = oo
+			return nil
+		})
+	}
+	if len(names) > 0 {
+		g.Go(func() error {
+			oo, err := a.resolveNames(names)
+			if err != nil {
+				return err
+			}
+			entities2 = oo
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return slices.Concat(entities1, entities2), nil
+}
+
+// newSpinner starts and returns a new spinner with a description.
+// Returns nil when the spinner is disabled.
+func (a App) newSpinner(description string) *progressbar.ProgressBar {
+	if a.SpinnerDisabled {
+		return nil
+	}
+	return progressbar.NewOptions(-1,
+		progressbar.OptionSpinnerType(14), // choose spinner style (0–39)
+		progressbar.OptionSetDescription(description),
+		progressbar.OptionSetRenderBlankState(true),
+		progressbar.OptionSetWriter(a.out),
+	)
+}
+
+func (a App) printResults(results []result) error {
+	var tables []*table
+	for _, r := range results {
+		if r.table != nil {
+			tables = append(tables, r.table)
+		}
+	}
+	columns := slices.Clone(a.Columns)
+	if a.Sort != "" {
+		columns = append(columns, strings.TrimPrefix(a.Sort, "-"))
+	}
+	if err := validateColumns(columns, tables); err != nil {
+		return err
+	}
 	for _, r := range results {
 		if r.table == nil {
 			continue
 		}
-		fmt.Fprintln(a.out, r.category.Display()+":")
-		r.table.Render()
+		fmt.Fprintln(a.out, r.title+":")
+		if err := a.renderTable(r.table); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -264,6 +422,10 @@ func (a App) resolveIDs(ids []int32) ([]EveEntity, error) {
 	if err != nil {
 		return nil, err
 	}
+	entities2, err = a.resolveCelestialIDs(entities2)
+	if err != nil {
+		return nil, err
+	}
 	entities3 := slices.DeleteFunc(slices.Clone(entities2), func(o EveEntity) bool {
 		return o.ID() == 0
 	})
@@ -281,6 +443,73 @@ func (a App) resolveIDs(ids []int32) ([]EveEntity, error) {
 	return entities, nil
 }
 
+// resolveCelestialIDs tries to resolve invalid entities with IDs of celestials and stargates,
+// which are not supported by the names endpoint, by fetching the objects directly.
+// It returns all entities with the resolved entities replaced.
+func (a App) resolveCelestialIDs(entities []EveEntity) ([]EveEntity, error) {
+	var celestialIDs, stargateIDs []int32
+	for _, e := range entities {
+		if e.Category != CategoryInvalid {
+			continue
+		}
+		switch id := e.ID(); {
+		case id >= celestialIDBegin && id < celestialIDEnd:
+			celestialIDs = append(celestialIDs, id)
+		case id >= stargateIDBegin && id < stargateIDEnd:
+			stargateIDs = append(stargateIDs, id)
+		}
+	}
+	if len(celestialIDs)+len(stargateIDs) == 0 {
+		return entities, nil
+	}
+	resolved := make(map[int32]EveEntity)
+	add := func(id int32, name string, category EveEntityCategory) {
+		resolved[id] = EveEntity{EntityID: id, Name: name, Category: category, Timestamp: now()}
+	}
+	remaining := func() []int32 {
+		return slices.DeleteFunc(slices.Clone(celestialIDs), func(id int32) bool {
+			_, ok := resolved[id]
+			return ok
+		})
+	}
+	planets, err := a.fetchPlanets(celestialIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range planets {
+		add(o.ID(), o.Name, CategoryPlanet)
+	}
+	moons, err := a.fetchMoons(remaining())
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range moons {
+		add(o.ID(), o.Name, CategoryMoon)
+	}
+	belts, err := a.fetchAsteroidBelts(remaining())
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range belts {
+		add(o.ID(), o.Name, CategoryAsteroidBelt)
+	}
+	stargates, err := a.fetchStargates(stargateIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range stargates {
+		add(o.ID(), o.Name, CategoryStargate)
+	}
+	entities2 := make([]EveEntity, 0, len(entities))
+	for _, e := range entities {
+		if o, ok := resolved[e.ID()]; ok {
+			e = o
+		}
+		entities2 = append(entities2, e)
+	}
+	return entities2, nil
+}
+
 func resolveIDsFromAPI(esiClient *goesi.APIClient, ids []int32) ([]EveEntity, error) {
 	ids2 := sliceUnique(ids)
 	entities := make([]EveEntity, 0)
@@ -350,13 +579,17 @@ func resolveIDsFromAPI3(esiClient *goesi.APIClient, ids []int32) ([]EveEntity, e
 	eveEntityCategoryFromESICategory := func(c string) EveEntityCategory {
 		categoryMap := map[string]EveEntityCategory{
 			"alliance":       CategoryAlliance,
+			"asteroid_belt":  CategoryAsteroidBelt,
 			"character":      CategoryCharacter,
 			"corporation":    CategoryCorporation,
 			"constellation":  CategoryConstellation,
 			"faction":        CategoryFaction,
 			"inventory_type": CategoryInventoryType,
+			"moon":           CategoryMoon,
+			"planet":         CategoryPlanet,
 			"region":         CategoryRegion,
 			"solar_system":   CategorySolarSystem,
+			"stargate":       CategoryStargate,
 			"station":        CategoryStation,
 		}
 		c2, ok := categoryMap[c]
@@ -381,13 +614,6 @@ func (a App) resolveNames(names []string) ([]EveEntity, error) {
 	if len(names) == 0 {
 		return []EveEntity{}, nil
 	}
-	data, r, err := a.esiClient.ESI.UniverseApi.PostUniverseIds(context.Background(), names, nil)
-	if err != nil {
-		return nil, err
-	}
-	if r.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned error: %s", r.Status)
-	}
 	matches := make(map[string]bool)
 	for _, n := range names {
 		matches[n] = true
@@ -406,35 +632,45 @@ func (a App) resolveNames(names []string) ([]EveEntity, error) {
 		})
 		found[name] = true
 	}
-	for _, o := range data.Agents {
-		addEntity(o.Id, o.Name, CategoryAgent)
-	}
-	for _, o := range data.Alliances {
-		addEntity(o.Id, o.Name, CategoryAlliance)
-	}
-	for _, o := range data.Characters {
-		addEntity(o.Id, o.Name, CategoryCharacter)
-	}
-	for _, o := range data.Constellations {
-		addEntity(o.Id, o.Name, CategoryConstellation)
-	}
-	for _, o := range data.Corporations {
-		addEntity(o.Id, o.Name, CategoryCorporation)
-	}
-	for _, o := range data.Factions {
-		addEntity(o.Id, o.Name, CategoryFaction)
-	}
-	for _, o := range data.InventoryTypes {
-		addEntity(o.Id, o.Name, CategoryInventoryType)
-	}
-	for _, o := range data.Regions {
-		addEntity(o.Id, o.Name, CategoryRegion)
-	}
-	for _, o := range data.Stations {
-		addEntity(o.Id, o.Name, CategoryStation)
-	}
-	for _, o := range data.Systems {
-		addEntity(o.Id, o.Name, CategorySolarSystem)
+	// The endpoint accepts at most 500 names per request
+	for chunk := range slices.Chunk(sliceUnique(names), 500) {
+		data, r, err := a.esiClient.ESI.UniverseApi.PostUniverseIds(context.Background(), chunk, nil)
+		if err != nil {
+			return nil, err
+		}
+		if r.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("API returned error: %s", r.Status)
+		}
+		for _, o := range data.Agents {
+			addEntity(o.Id, o.Name, CategoryAgent)
+		}
+		for _, o := range data.Alliances {
+			addEntity(o.Id, o.Name, CategoryAlliance)
+		}
+		for _, o := range data.Characters {
+			addEntity(o.Id, o.Name, CategoryCharacter)
+		}
+		for _, o := range data.Constellations {
+			addEntity(o.Id, o.Name, CategoryConstellation)
+		}
+		for _, o := range data.Corporations {
+			addEntity(o.Id, o.Name, CategoryCorporation)
+		}
+		for _, o := range data.Factions {
+			addEntity(o.Id, o.Name, CategoryFaction)
+		}
+		for _, o := range data.InventoryTypes {
+			addEntity(o.Id, o.Name, CategoryInventoryType)
+		}
+		for _, o := range data.Regions {
+			addEntity(o.Id, o.Name, CategoryRegion)
+		}
+		for _, o := range data.Stations {
+			addEntity(o.Id, o.Name, CategoryStation)
+		}
+		for _, o := range data.Systems {
+			addEntity(o.Id, o.Name, CategorySolarSystem)
+		}
 	}
 	for _, n := range names {
 		if found[n] {
@@ -455,7 +691,7 @@ func (a App) resolveNames(names []string) ([]EveEntity, error) {
 	return entities, nil
 }
 
-func (a App) buildCharacterTable(ids []int32) (*tablewriter.Table, error) {
+func (a App) buildCharacterTable(ids []int32) (*table, error) {
 	characters, err := a.fetchCharacters(ids)
 	if err != nil {
 		return nil, err
@@ -466,66 +702,299 @@ func (a App) buildCharacterTable(ids []int32) (*tablewriter.Table, error) {
 		if o.AllianceID != 0 {
 			entityIDs = append(entityIDs, o.AllianceID)
 		}
+		if o.FactionID != 0 {
+			entityIDs = append(entityIDs, o.FactionID)
+		}
 	}
 	ee, err := a.resolveIDs(entityIDs)
 	if err != nil {
 		return nil, err
 	}
 	entityLookup := makeLookupMap(ee)
+	if a.Detail {
+		var raceIDs, bloodlineIDs []int32
+		for _, o := range characters {
+			if o.RaceID != 0 {
+				raceIDs = append(raceIDs, o.RaceID)
+			}
+			if o.BloodlineID != 0 {
+				bloodlineIDs = append(bloodlineIDs, o.BloodlineID)
+			}
+		}
+		races, err := a.fetchRaces(raceIDs)
+		if err != nil {
+			return nil, err
+		}
+		raceLookup := makeLookupMap(races)
+		bloodlines, err := a.fetchBloodlines(bloodlineIDs)
+		if err != nil {
+			return nil, err
+		}
+		bloodlineLookup := makeLookupMap(bloodlines)
+		// Affiliations are refreshed daily, but security status, title and description only weekly.
+		t := makeSortedTable(
+			a,
+			[]string{"ID", "Name", "Title", "CorporationID", "CorporationName", "AllianceID", "AllianceName", "FactionID", "FactionName", "Birthday", "Age", "Security", "Gender", "Race", "Bloodline", "Description", "NPC"},
+			characters,
+			func(o EveCharacter) []any {
+				return []any{
+					o.ID(),
+					o.Name,
+					o.Title,
+					o.CorporationID,
+					entityLookup[o.CorporationID].Name,
+					idOrEmpty(o.AllianceID),
+					entityLookup[o.AllianceID].Name,
+					idOrEmpty(o.FactionID),
+					entityLookup[o.FactionID].Name,
+					formatDate(o.Birthday),
+					o.Age(),
+					formatSecurity(o.SecurityStatus),
+					o.Gender,
+					raceLookup[o.RaceID].Name,
+					bloodlineLookup[o.BloodlineID].Name,
+					stripTags(o.Description),
+					o.IsNPC(),
+				}
+			})
+		return t, nil
+	}
+	// Affiliations are refreshed daily, but the security status only weekly with the other details.
 	t := makeSortedTable(
 		a,
-		[]string{"ID", "Name", "CorporationID", "CorporationName", "AllianceID", "AllianceName", "NPC"},
+		[]string{"ID", "Name", "CorporationID", "CorporationName", "AllianceID", "AllianceName", "Age", "Security", "NPC"},
 		characters,
 		func(o EveCharacter) []any {
 			corporationName := entityLookup[o.CorporationID].Name
-			return []any{o.ID(), o.Name, o.CorporationID, corporationName, idOrEmpty(o.AllianceID), entityLookup[o.AllianceID].Name, o.IsNPC()}
+			return []any{o.ID(), o.Name, o.CorporationID, corporationName, idOrEmpty(o.AllianceID), entityLookup[o.AllianceID].Name, o.Age(), formatSecurity(o.SecurityStatus), o.IsNPC()}
 		})
 	return t, nil
 }
 
+// fetchCharacters returns the characters for the given IDs.
+// Stale characters from storage with recent details are refreshed in bulk with their current affiliation.
+// Unknown characters and characters with stale details are fetched one by one.
 func (a App) fetchCharacters(ids []int32) ([]EveCharacter, error) {
+	fresh, missing, err := a.st.ListFreshEveCharacterByID(sliceUnique(ids))
+	if err != nil {
+		return nil, err
+	}
+	stale, unknown, err := a.st.ListEveCharacterByID(missing)
+	if err != nil {
+		return nil, err
+	}
+	var staleAffiliations []EveCharacter
+	for _, o := range stale {
+		if o.HasStaleDetails() {
+			unknown = append(unknown, o.ID())
+		} else {
+			staleAffiliations = append(staleAffiliations, o)
+		}
+	}
+	refreshed, notRefreshed, err := a.refreshCharacterAffiliations(staleAffiliations)
+	if err != nil {
+		return nil, err
+	}
 	oo, _, err := fetchObjects(
-		ids,
+		slices.Concat(unknown, notRefreshed),
 		a.st.ListFreshEveCharacterByID,
 		func(id int32) (esi.GetCharactersCharacterIdOk, *http.Response, error) {
 			return a.esiClient.ESI.CharacterApi.GetCharactersCharacterId(context.Background(), id, nil)
 		},
 		func(id int32, x esi.GetCharactersCharacterIdOk) EveCharacter {
 			return EveCharacter{
-				AllianceID:    x.AllianceId,
-				CharacterID:   id,
-				CorporationID: x.CorporationId,
-				Name:          x.Name,
-				Timestamp:     now(),
+				AllianceID:       x.AllianceId,
+				Birthday:         x.Birthday,
+				BloodlineID:      x.BloodlineId,
+				CharacterID:      id,
+				CorporationID:    x.CorporationId,
+				Description:      x.Description,
+				DetailsTimestamp: now(),
+				FactionID:        x.FactionId,
+				Gender:           x.Gender,
+				Name:             x.Name,
+				RaceID:           x.RaceId,
+				SecurityStatus:   x.SecurityStatus,
+				Timestamp:        now(),
+				Title:            x.Title,
 			}
 		},
 		a.st.UpdateOrCreateEveCharacter,
 	)
-	return oo, err
-}
-
-func (a App) buildCorporationTable(ids []int32) (*tablewriter.Table, error) {
-	corporations, err := a.fetchCorporations(ids)
 	if err != nil {
 		return nil, err
 	}
-	var entityIDs []int32
-	for _, o := range corporations {
-		if o.AllianceID != 0 {
-			entityIDs = append(entityIDs, o.AllianceID)
+	return slices.Concat(fresh, refreshed, oo), nil
+}
+
+// refreshCharacterAffiliations updates the corporation, alliance and faction of characters
+// with the affiliation endpoint, which accepts up to 1000 characters per request.
+// It returns the refreshed characters and the IDs of characters which could not be refreshed.
+func (a App) refreshCharacterAffiliations(characters []EveCharacter) ([]EveCharacter, []int32, error) {
+	if len(characters) == 0 {
+		return nil, nil, nil
+	}
+	ids := make([]int32, 0, len(characters))
+	for _, o := range characters {
+		ids = append(ids, o.ID())
+	}
+	affiliations := make(map[int32]esi.PostCharactersAffiliation200Ok)
+	for idsChunk := range slices.Chunk(ids, 1000) {
+		data, _, err := a.esiClient.ESI.CharacterApi.PostCharactersAffiliation(context.Background(), idsChunk, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("refresh character affiliations: %w", err)
+		}
+		for _, x := range data {
+			affiliations[x.CharacterId] = x
 		}
 	}
-	entities, err := a.resolveIDs(entityIDs)
+	var refreshed []EveCharacter
+	var notRefreshed []int32
+	for _, o := range characters {
+		x, ok := affiliations[o.ID()]
+		if !ok {
+			notRefreshed = append(notRefreshed, o.ID())
+			continue
+		}
+		o.AllianceID = x.AllianceId
+		o.CorporationID = x.CorporationId
+		o.FactionID = x.FactionId
+		o.Timestamp = now()
+		refreshed = append(refreshed, o)
+	}
+	if err := a.st.UpdateOrCreateEveCharacter(refreshed); err != nil {
+		return nil, nil, err
+	}
+	slog.Info("Refreshed character affiliations", "count", len(refreshed))
+	return refreshed, notRefreshed, nil
+}
+
+func (a App) fetchRaces(ids []int32) ([]EveRace, error) {
+	oo, _, err := fetchObjects(
+		ids,
+		a.st.ListFreshEveRaceByID,
+		func(id int32) ([]esi.GetUniverseRaces200Ok, *http.Response, error) {
+			return a.esiClient.ESI.UniverseApi.GetUniverseRaces(context.Background(), nil)
+		},
+		func(id int32, xx []esi.GetUniverseRaces200Ok) EveRace {
+			for _, x := range xx {
+				if x.RaceId != id {
+					continue
+				}
+				return EveRace{
+					AllianceID: x.AllianceId,
+					Name:       x.Name,
+					RaceID:     id,
+					Timestamp:  now(),
+				}
+			}
+			return EveRace{
+				RaceID:    id,
+				Name:      nameInvalid,
+				Timestamp: now(),
+			}
+		},
+		a.st.UpdateOrCreateEveRace,
+	)
+	return oo, err
+}
+
+func (a App) fetchBloodlines(ids []int32) ([]EveBloodline, error) {
+	oo, _, err := fetchObjects(
+		ids,
+		a.st.ListFreshEveBloodlineByID,
+		func(id int32) ([]esi.GetUniverseBloodlines200Ok, *http.Response, error) {
+			return a.esiClient.ESI.UniverseApi.GetUniverseBloodlines(context.Background(), nil)
+		},
+		func(id int32, xx []esi.GetUniverseBloodlines200Ok) EveBloodline {
+			for _, x := range xx {
+				if x.BloodlineId != id {
+					continue
+				}
+				return EveBloodline{
+					BloodlineID:   id,
+					CorporationID: x.CorporationId,
+					Name:          x.Name,
+					RaceID:        x.RaceId,
+					Timestamp:     now(),
+				}
+			}
+			return EveBloodline{
+				BloodlineID: id,
+				Name:        nameInvalid,
+				Timestamp:   now(),
+			}
+		},
+		a.st.UpdateOrCreateEveBloodline,
+	)
+	return oo, err
+}
+
+func (a App) buildCorporationTable(ids []int32) (*table, error) {
+	corporations, err := a.fetchCorporations(ids)
+	if err != nil {
+		return nil, err
+	}
+	var entityIDs []int32
+	for _, o := range corporations {
+		entityIDs = append(entityIDs, o.AllianceID, o.CeoID, o.CreatorID, o.FactionID, o.HomeStationID)
+	}
+	entityIDs = slices.DeleteFunc(entityIDs, func(id int32) bool {
+		return id == 0
+	})
+	entities, err := a.resolveIDs(entityIDs)
 	if err != nil {
 		return nil, err
 	}
 	entityLookup := makeLookupMap(entities)
+	if a.Detail {
+		t := makeSortedTable(
+			a,
+			[]string{"ID", "Name", "Ticker", "Members", "AllianceID", "AllianceName", "CeoID", "CeoName", "CreatorID", "CreatorName", "DateFounded", "HomeStationID", "HomeStationName", "FactionID", "FactionName", "TaxRate", "URL", "WarEligible", "NPC"},
+			corporations,
+			func(o EveCorporation) []any {
+				return []any{
+					o.ID(),
+					o.Name,
+					o.Ticker,
+					o.MemberCount,
+					idOrEmpty(o.AllianceID),
+					entityLookup[o.AllianceID].Name,
+					idOrEmpty(o.CeoID),
+					entityLookup[o.CeoID].Name,
+					idOrEmpty(o.CreatorID),
+					entityLookup[o.CreatorID].Name,
+					formatDate(o.DateFounded),
+					idOrEmpty(o.HomeStationID),
+					entityLookup[o.HomeStationID].Name,
+					idOrEmpty(o.FactionID),
+					entityLookup[o.FactionID].Name,
+					formatPercent(o.TaxRate),
+					o.URL,
+					o.WarEligible,
+					o.IsNPC(),
+				}
+			})
+		return t, nil
+	}
 	t := makeSortedTable(
 		a,
-		[]string{"ID", "Name", "Ticker", "Members", "AllianceID", "AllianceName", "NPC"},
+		[]string{"ID", "Name", "Ticker", "Members", "AllianceID", "AllianceName", "CeoName", "DateFounded", "TaxRate", "WarEligible", "NPC"},
 		corporations,
 		func(o EveCorporation) []any {
-			return []any{o.ID(), o.Name, o.Ticker, o.MemberCount, idOrEmpty(o.AllianceID), entityLookup[o.AllianceID].Name, o.IsNPC()}
+			return []any{
+				o.ID(),
+				o.Name,
+				o.Ticker,
+				o.MemberCount,
+				idOrEmpty(o.AllianceID),
+				entityLookup[o.AllianceID].Name,
+				entityLookup[o.CeoID].Name,
+				formatDate(o.DateFounded),
+				formatPercent(o.TaxRate),
+				o.WarEligible,
+				o.IsNPC(),
+			}
 		})
 	return t, err
 }
@@ -542,10 +1011,17 @@ func (a App) fetchCorporations(ids []int32) ([]EveCorporation, error) {
 				AllianceID:    x.AllianceId,
 				CeoID:         x.CeoId,
 				CorporationID: id,
+				CreatorID:     x.CreatorId,
+				DateFounded:   x.DateFounded,
+				FactionID:     x.FactionId,
+				HomeStationID: x.HomeStationId,
 				MemberCount:   x.MemberCount,
 				Name:          x.Name,
+				TaxRate:       x.TaxRate,
 				Ticker:        x.Ticker,
 				Timestamp:     now(),
+				URL:           x.Url,
+				WarEligible:   x.WarEligible,
 			}
 		},
 		a.st.UpdateOrCreateEveCorporation,
@@ -553,17 +1029,52 @@ func (a App) fetchCorporations(ids []int32) ([]EveCorporation, error) {
 	return oo, err
 }
 
-func (a App) buildAllianceTable(ids []int32) (*tablewriter.Table, error) {
+func (a App) buildAllianceTable(ids []int32) (*table, error) {
 	alliances, err := a.fetchAlliance(ids)
 	if err != nil {
 		return nil, err
 	}
+	var entityIDs []int32
+	for _, o := range alliances {
+		entityIDs = append(entityIDs, o.ExecutorCorporationID, o.CreatorID, o.CreatorCorporationID, o.FactionID)
+	}
+	entityIDs = slices.DeleteFunc(entityIDs, func(id int32) bool {
+		return id == 0
+	})
+	entities, err := a.resolveIDs(entityIDs)
+	if err != nil {
+		return nil, err
+	}
+	entityLookup := makeLookupMap(entities)
+	if a.Detail {
+		t := makeSortedTable(
+			a,
+			[]string{"ID", "Name", "Ticker", "DateFounded", "ExecutorCorporationID", "ExecutorCorporationName", "CreatorID", "CreatorName", "CreatorCorporationID", "CreatorCorporationName", "FactionID", "FactionName"},
+			alliances,
+			func(o EveAlliance) []any {
+				return []any{
+					o.ID(),
+					o.Name,
+					o.Ticker,
+					formatDate(o.DateFounded),
+					idOrEmpty(o.ExecutorCorporationID),
+					entityLookup[o.ExecutorCorporationID].Name,
+					idOrEmpty(o.CreatorID),
+					entityLookup[o.CreatorID].Name,
+					idOrEmpty(o.CreatorCorporationID),
+					entityLookup[o.CreatorCorporationID].Name,
+					idOrEmpty(o.FactionID),
+					entityLookup[o.FactionID].Name,
+				}
+			})
+		return t, nil
+	}
 	t := makeSortedTable(
 		a,
-		[]string{"ID", "Name", "Ticker"},
+		[]string{"ID", "Name", "Ticker", "DateFounded", "ExecutorCorporationName"},
 		alliances,
 		func(o EveAlliance) []any {
-			return []any{o.ID(), o.Name, o.Ticker}
+			return []any{o.ID(), o.Name, o.Ticker, formatDate(o.DateFounded), entityLookup[o.ExecutorCorporationID].Name}
 		})
 	return t, nil
 }
@@ -577,10 +1088,15 @@ func (a App) fetchAlliance(ids []int32) ([]EveAlliance, error) {
 		},
 		func(id int32, x esi.GetAlliancesAllianceIdOk) EveAlliance {
 			return EveAlliance{
-				AllianceID: id,
-				Name:       x.Name,
-				Ticker:     x.Ticker,
-				Timestamp:  now(),
+				AllianceID:            id,
+				CreatorCorporationID:  x.CreatorCorporationId,
+				CreatorID:             x.CreatorId,
+				DateFounded:           x.DateFounded,
+				ExecutorCorporationID: x.ExecutorCorporationId,
+				FactionID:             x.FactionId,
+				Name:                  x.Name,
+				Ticker:                x.Ticker,
+				Timestamp:             now(),
 			}
 		},
 		a.st.UpdateOrCreateEveAlliance,
@@ -588,31 +1104,63 @@ func (a App) fetchAlliance(ids []int32) ([]EveAlliance, error) {
 	return oo, err
 }
 
-func (a App) buildFactionTable(ids []int32) (*tablewriter.Table, error) {
+func (a App) buildFactionTable(ids []int32) (*table, error) {
 	factions, err := a.fetchFactions(ids)
 	if err != nil {
 		return nil, err
 	}
 	var entityIDs []int32
 	for _, o := range factions {
-		if o.CorporationID != 0 {
-			entityIDs = append(entityIDs, o.CorporationID)
-		}
-		if o.MilitiaCorporationID != 0 {
-			entityIDs = append(entityIDs, o.MilitiaCorporationID)
-		}
+		entityIDs = append(entityIDs, o.CorporationID, o.MilitiaCorporationID, o.SolarSystemID)
 	}
+	entityIDs = slices.DeleteFunc(entityIDs, func(id int32) bool {
+		return id == 0
+	})
 	entities, err := a.resolveIDs(entityIDs)
 	if err != nil {
 		return nil, err
 	}
 	entityLookup := makeLookupMap(entities)
+	if a.Detail {
+		t := makeSortedTable(
+			a,
+			[]string{"ID", "Name", "CorporationID", "CorporationName", "MilitiaCorporationID", "MilitiaCorporationName", "HomeSolarSystemID", "HomeSolarSystemName", "Stations", "StationSystems", "SizeFactor", "Unique", "Description"},
+			factions,
+			func(o EveFaction) []any {
+				return []any{
+					o.ID(),
+					o.Name,
+					idOrEmpty(o.CorporationID),
+					entityLookup[o.CorporationID].Name,
+					idOrEmpty(o.MilitiaCorporationID),
+					entityLookup[o.MilitiaCorporationID].Name,
+					idOrEmpty(o.SolarSystemID),
+					entityLookup[o.SolarSystemID].Name,
+					o.StationCount,
+					o.StationSystemCount,
+					o.SizeFactor,
+					o.IsUnique,
+					stripTags(o.Description),
+				}
+			})
+		return t, nil
+	}
 	t := makeSortedTable(
 		a,
-		[]string{"ID", "Name", "CorporationID", "CorporationName", "MilitiaCorporationID", "MilitiaCorporationName"},
+		[]string{"ID", "Name", "CorporationID", "CorporationName", "MilitiaCorporationID", "MilitiaCorporationName", "HomeSolarSystemName", "Stations", "StationSystems"},
 		factions,
 		func(o EveFaction) []any {
-			return []any{o.ID(), o.Name, idOrEmpty(o.CorporationID), entityLookup[o.CorporationID].Name, idOrEmpty(o.MilitiaCorporationID), entityLookup[o.MilitiaCorporationID].Name}
+			return []any{
+				o.ID(),
+				o.Name,
+				idOrEmpty(o.CorporationID),
+				entityLookup[o.CorporationID].Name,
+				idOrEmpty(o.MilitiaCorporationID),
+				entityLookup[o.MilitiaCorporationID].Name,
+				entityLookup[o.SolarSystemID].Name,
+				o.StationCount,
+				o.StationSystemCount,
+			}
 		})
 	return t, nil
 }
@@ -630,10 +1178,16 @@ func (a App) fetchFactions(ids []int32) ([]EveFaction, error) {
 					continue
 				}
 				return EveFaction{
-					FactionID:            id,
 					CorporationID:        x.CorporationId,
+					Description:          x.Description,
+					FactionID:            id,
+					IsUnique:             x.IsUnique,
 					MilitiaCorporationID: x.MilitiaCorporationId,
 					Name:                 x.Name,
+					SizeFactor:           x.SizeFactor,
+					SolarSystemID:        x.SolarSystemId,
+					StationCount:         x.StationCount,
+					StationSystemCount:   x.StationSystemCount,
 					Timestamp:            now(),
 				}
 			}
@@ -648,7 +1202,7 @@ func (a App) fetchFactions(ids []int32) ([]EveFaction, error) {
 	return oo, err
 }
 
-func (a App) buildStationTable(ids []int32) (*tablewriter.Table, error) {
+func (a App) buildStationTable(ids []int32) (*table, error) {
 	stations, err := a.fetchStations(ids)
 	if err != nil {
 		return nil, err
@@ -664,6 +1218,27 @@ func (a App) buildStationTable(ids []int32) (*tablewriter.Table, error) {
 		return nil, err
 	}
 	entityLookup := makeLookupMap(entities)
+	if a.Detail {
+		var solarSystemIDs []int32
+		for _, o := range stations {
+			solarSystemIDs = append(solarSystemIDs, o.SolarSystemID)
+		}
+		locations, err := a.fetchLocations(solarSystemIDs)
+		if err != nil {
+			return nil, err
+		}
+		t := makeSortedTable(
+			a,
+			[]string{"ID", "Name", "SolarSystemID", "SolarSystemName", "Security", "ConstellationID", "ConstellationName", "RegionID", "RegionName", "TypeID", "TypeName", "OwnerID", "OwnerName"},
+			stations,
+			func(o EveStation) []any {
+				l := locations[o.SolarSystemID]
+				typeName := entityLookup[o.TypeID].Name
+				ownerName := entityLookup[o.OwnerID].Name
+				return []any{o.StationID, o.Name, o.SolarSystemID, l.solarSystem.Name, l.solarSystem.Security, l.constellation.ConstellationID, l.constellation.Name, l.region.RegionID, l.region.Name, o.TypeID, typeName, o.OwnerID, ownerName}
+			})
+		return t, nil
+	}
 	t := makeSortedTable(
 		a,
 		[]string{"ID", "Name", "SolarSystemID", "SolarSystemName", "TypeID", "TypeName", "OwnerID", "OwnerName"},
@@ -699,7 +1274,7 @@ func (a App) fetchStations(ids []int32) ([]EveStation, error) {
 	return oo, err
 }
 
-func (a App) buildTypeTable(ids []int32) (*tablewriter.Table, error) {
+func (a App) buildTypeTable(ids []int32) (*table, error) {
 	types, err := a.fetchTypes(ids)
 	if err != nil {
 		return nil, err
@@ -722,14 +1297,61 @@ func (a App) buildTypeTable(ids []int32) (*tablewriter.Table, error) {
 		return nil, err
 	}
 	categoryLookup := makeLookupMap(categories)
+	addPrices, err := a.makeTypePriceColumns(ids)
+	if err != nil {
+		return nil, err
+	}
+	if a.Detail {
+		attributes, err := a.fetchTypeAttributes(types)
+		if err != nil {
+			return nil, err
+		}
+		var marketGroupIDs []int32
+		for _, o := range types {
+			if o.MarketGroupID != 0 {
+				marketGroupIDs = append(marketGroupIDs, o.MarketGroupID)
+			}
+		}
+		marketGroupPaths, err := a.fetchMarketGroupPaths(marketGroupIDs)
+		if err != nil {
+			return nil, err
+		}
+		t := makeSortedTable(
+			a,
+			addPrices.headers([]string{"ID", "Name", "GroupID", "GroupName", "CategoryID", "CategoryName", "Published", "Mass", "Volume", "PackagedVolume", "Capacity", "MarketGroupID", "MarketGroup", "IconID", "Description", "Attributes"}),
+			types,
+			func(o EveType) []any {
+				group := groupLookup[o.GroupID]
+				category := categoryLookup[group.CategoryID]
+				return addPrices.values(o.ID(), []any{
+					o.TypeID,
+					o.Name,
+					group.GroupID,
+					group.Name,
+					category.CategoryID,
+					category.Name,
+					o.Published,
+					formatFloat(o.Mass),
+					formatFloat(o.Volume),
+					formatFloat(o.PackagedVolume),
+					formatFloat(o.Capacity),
+					idOrEmpty(o.MarketGroupID),
+					marketGroupPaths[o.MarketGroupID],
+					idOrEmpty(o.IconID),
+					stripTags(o.Description),
+					attributes[o.ID()],
+				})
+			})
+		return t, nil
+	}
 	t := makeSortedTable(
 		a,
-		[]string{"ID", "Name", "GroupID", "GroupName", "CategoryID", "CategoryName", "Published"},
+		addPrices.headers([]string{"ID", "Name", "GroupID", "GroupName", "CategoryID", "CategoryName", "Published"}),
 		types,
 		func(o EveType) []any {
 			group := groupLookup[o.GroupID]
 			category := categoryLookup[group.CategoryID]
-			return []any{o.TypeID, o.Name, group.GroupID, group.Name, category.CategoryID, category.Name, o.Published}
+			return addPrices.values(o.ID(), []any{o.TypeID, o.Name, group.GroupID, group.Name, category.CategoryID, category.Name, o.Published})
 		})
 	return t, nil
 }
@@ -742,12 +1364,29 @@ func (a App) fetchTypes(ids []int32) ([]EveType, error) {
 			return a.esiClient.ESI.UniverseApi.GetUniverseTypesTypeId(context.Background(), id, nil)
 		},
 		func(id int32, x esi.GetUniverseTypesTypeIdOk) EveType {
+			var attributes []EveTypeDogmaAttribute
+			for _, y := range x.DogmaAttributes {
+				attributes = append(attributes, EveTypeDogmaAttribute{AttributeID: y.AttributeId, Value: y.Value})
+			}
+			effects := make([]EveTypeDogmaEffect, 0) // never nil to tell it apart from types cached without effects
+			for _, y := range x.DogmaEffects {
+				effects = append(effects, EveTypeDogmaEffect{EffectID: y.EffectId, IsDefault: y.IsDefault})
+			}
 			return EveType{
-				GroupID:   x.GroupId,
-				TypeID:    id,
-				Name:      x.Name,
-				Published: x.Published,
-				Timestamp: now(),
+				Capacity:        x.Capacity,
+				Description:     x.Description,
+				DogmaAttributes: attributes,
+				DogmaEffects:    effects,
+				GroupID:         x.GroupId,
+				IconID:          x.IconId,
+				MarketGroupID:   x.MarketGroupId,
+				Mass:            x.Mass,
+				Name:            x.Name,
+				PackagedVolume:  x.PackagedVolume,
+				Published:       x.Published,
+				Timestamp:       now(),
+				TypeID:          id,
+				Volume:          x.Volume,
 			}
 		},
 		a.st.UpdateOrCreateEveType,
@@ -755,6 +1394,114 @@ func (a App) fetchTypes(ids []int32) ([]EveType, error) {
 	return oo, err
 }
 
+// fetchTypeAttributes returns the published dogma attributes of inventory types by type ID.
+// Each attribute is shown with its display name and value on a separate line, sorted by name.
+func (a App) fetchTypeAttributes(types []EveType) (map[int32]string, error) {
+	var ids []int32
+	for _, o := range types {
+		for _, x := range o.DogmaAttributes {
+			ids = append(ids, x.AttributeID)
+		}
+	}
+	attributes, err := a.fetchDogmaAttributes(ids)
+	if err != nil {
+		return nil, err
+	}
+	attributeLookup := makeLookupMap(attributes)
+	m := make(map[int32]string)
+	for _, o := range types {
+		var lines []string
+		for _, x := range o.DogmaAttributes {
+			da, ok := attributeLookup[x.AttributeID]
+			if !ok || !da.Published {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s: %s", da.DisplayNameOrName(), formatFloat(x.Value)))
+		}
+		slices.Sort(lines)
+		m[o.ID()] = strings.Join(lines, "\n")
+	}
+	return m, nil
+}
+
+func (a App) fetchDogmaAttributes(ids []int32) ([]EveDogmaAttribute, error) {
+	oo, _, err := fetchObjects(
+		ids,
+		a.st.ListFreshEveDogmaAttributeByID,
+		func(id int32) (esi.GetDogmaAttributesAttributeIdOk, *http.Response, error) {
+			return a.esiClient.ESI.DogmaApi.GetDogmaAttributesAttributeId(context.Background(), id, nil)
+		},
+		func(id int32, x esi.GetDogmaAttributesAttributeIdOk) EveDogmaAttribute {
+			return EveDogmaAttribute{
+				AttributeID:  id,
+				DefaultValue: x.DefaultValue,
+				Description:  x.Description,
+				DisplayName:  x.DisplayName,
+				HighIsGood:   x.HighIsGood,
+				Name:         x.Name,
+				Published:    x.Published,
+				Timestamp:    now(),
+				UnitID:       x.UnitId,
+			}
+		},
+		a.st.UpdateOrCreateEveDogmaAttribute,
+	)
+	return oo, err
+}
+
+func (a App) fetchMarketGroups(ids []int32) ([]EveMarketGroup, error) {
+	oo, _, err := fetchObjects(
+		ids,
+		a.st.ListFreshEveMarketGroupByID,
+		func(id int32) (esi.GetMarketsGroupsMarketGroupIdOk, *http.Response, error) {
+			return a.esiClient.ESI.MarketApi.GetMarketsGroupsMarketGroupId(context.Background(), id, nil)
+		},
+		func(id int32, x esi.GetMarketsGroupsMarketGroupIdOk) EveMarketGroup {
+			return EveMarketGroup{
+				Description:   x.Description,
+				MarketGroupID: id,
+				Name:          x.Name,
+				ParentGroupID: x.ParentGroupId,
+				Timestamp:     now(),
+				TypeIDs:       x.Types,
+			}
+		},
+		a.st.UpdateOrCreateEveMarketGroup,
+	)
+	return oo, err
+}
+
+// fetchMarketGroupPaths returns the full path of market groups by their ID,
+// e.g. "Ships > Frigates > Standard Frigates > Caldari".
+func (a App) fetchMarketGroupPaths(ids []int32) (map[int32]string, error) {
+	lookup := make(map[int32]EveMarketGroup)
+	for pending := ids; len(pending) > 0; {
+		groups, err := a.fetchMarketGroups(pending)
+		if err != nil {
+			return nil, err
+		}
+		pending = nil
+		for _, o := range groups {
+			lookup[o.ID()] = o
+		}
+		for _, o := range groups {
+			if _, ok := lookup[o.ParentGroupID]; !ok && o.ParentGroupID != 0 {
+				pending = append(pending, o.ParentGroupID)
+			}
+		}
+	}
+	paths := make(map[int32]string)
+	for _, id := range ids {
+		var names []string
+		for o, ok := lookup[id]; ok && len(names) <= len(lookup); o, ok = lookup[o.ParentGroupID] {
+			names = append(names, o.Name)
+		}
+		slices.Reverse(names)
+		paths[id] = strings.Join(names, " > ")
+	}
+	return paths, nil
+}
+
 func (a App) fetchCategories(ids []int32) ([]EveCategory, error) {
 	oo, _, err := fetchObjects(
 		ids,
@@ -796,13 +1543,228 @@ func (a App) fetchGroups(ids []int32) ([]EveGroup, error) {
 	return oo, err
 }
 
-func (a App) buildSolarSystemTable(ids []int32) (*tablewriter.Table, error) {
-	types, err := a.fetchSolarSystems(ids)
+func (a App) buildSolarSystemTable(ids []int32) (*table, error) {
+	locations, err := a.fetchLocations(ids)
+	if err != nil {
+		return nil, err
+	}
+	systems := make([]EveSolarSystem, 0)
+	for _, l := range locations {
+		systems = append(systems, l.solarSystem)
+	}
+	sovereignty, err := a.fetchSovereignty(ids)
+	if err != nil {
+		return nil, err
+	}
+	holders, err := a.fetchSovereigntyHolders(sovereignty)
+	if err != nil {
+		return nil, err
+	}
+	var activity map[int32]EveSolarSystemActivity
+	if a.Activity {
+		activity, err = a.fetchActivity(ids)
+		if err != nil {
+			return nil, err
+		}
+	}
+	addActivity := func(headers []string) []string {
+		if !a.Activity {
+			return headers
+		}
+		return slices.Concat(headers, activityHeaders)
+	}
+	addActivityValues := func(id int32, values []any) []any {
+		if !a.Activity {
+			return values
+		}
+		return slices.Concat(values, activityValues(activity[id]))
+	}
+	if a.Detail {
+		contents, err := a.fetchSolarSystemContents(systems)
+		if err != nil {
+			return nil, err
+		}
+		t := makeSortedTable(
+			a,
+			addActivity([]string{"ID", "Name", "ConstellationID", "ConstellationName", "RegionID", "RegionName", "Security", "Sovereignty", "ADM", "Star", "SpectralClass", "Planets", "Moons", "AsteroidBelts", "Stargates", "Stations", "Contents"}),
+			systems,
+			func(o EveSolarSystem) []any {
+				l := locations[o.ID()]
+				c := contents[o.ID()]
+				return addActivityValues(o.ID(), []any{
+					o.ID(),
+					o.Name,
+					l.constellation.ConstellationID,
+					l.constellation.Name,
+					l.region.RegionID,
+					l.region.Name,
+					formatSecurity(o.DisplaySecurity()),
+					holders[o.ID()],
+					formatADM(sovereignty[o.ID()]),
+					c.star.Name,
+					c.star.SpectralClass,
+					len(o.Planets),
+					o.MoonCount(),
+					o.AsteroidBeltCount(),
+					len(o.StargateIDs),
+					len(o.StationIDs),
+					c.tree,
+				})
+			})
+		return t, nil
+	}
+	t := makeSortedTable(
+		a,
+		addActivity([]string{"ID", "Name", "ConstellationID", "ConstellationName", "RegionID", "RegionName", "Security", "Sovereignty", "Planets", "Moons", "Stargates", "Stations"}),
+		systems,
+		func(o EveSolarSystem) []any {
+			l := locations[o.ID()]
+			return addActivityValues(o.ID(), []any{
+				o.ID(),
+				o.Name,
+				l.constellation.ConstellationID,
+				l.constellation.Name,
+				l.region.RegionID,
+				l.region.Name,
+				o.Security,
+				holders[o.ID()],
+				len(o.Planets),
+				o.MoonCount(),
+				len(o.StargateIDs),
+				len(o.StationIDs),
+			})
+		})
+	return t, nil
+}
+
+type solarSystemContents struct {
+	star EveStar
+	tree string // celestials, stargates and stations of a solar system as tree
+}
+
+// fetchSolarSystemContents fetches the objects in solar systems and returns them by solar system ID.
+func (a App) fetchSolarSystemContents(systems []EveSolarSystem) (map[int32]solarSystemContents, error) {
+	var starIDs, planetIDs, moonIDs, beltIDs, stargateIDs, stationIDs []int32
+	for _, o := range systems {
+		if o.StarID != 0 {
+			starIDs = append(starIDs, o.StarID)
+		}
+		for _, p := range o.Planets {
+			planetIDs = append(planetIDs, p.PlanetID)
+			moonIDs = append(moonIDs, p.MoonIDs...)
+			beltIDs = append(beltIDs, p.AsteroidBeltIDs...)
+		}
+		stargateIDs = append(stargateIDs, o.StargateIDs...)
+		stationIDs = append(stationIDs, o.StationIDs...)
+	}
+	var (
+		stars     []EveStar
+		planets   []EvePlanet
+		moons     []EveMoon
+		belts     []EveAsteroidBelt
+		stargates []EveStargate
+		stations  []EveStation
+	)
+	g := new(errgroup.Group)
+	g.Go(func() error {
+		var err error
+		stars, err = a.fetchStars(starIDs)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		planets, err = a.fetchPlanets(planetIDs)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		moons, err = a.fetchMoons(moonIDs)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		belts, err = a.fetchAsteroidBelts(beltIDs)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		stargates, err = a.fetchStargates(stargateIDs)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		stations, err = a.fetchStations(stationIDs)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	var typeIDs []int32
+	for _, o := range planets {
+		typeIDs = append(typeIDs, o.TypeID)
+	}
+	types, err := a.fetchTypes(typeIDs)
+	if err != nil {
+		return nil, err
+	}
+	starLookup := makeLookupMap(stars)
+	planetLookup := makeLookupMap(planets)
+	moonLookup := makeLookupMap(moons)
+	beltLookup := makeLookupMap(belts)
+	stargateLookup := makeLookupMap(stargates)
+	stationLookup := makeLookupMap(stations)
+	typeLookup := makeLookupMap(types)
+	contents := make(map[int32]solarSystemContents)
+	for _, o := range systems {
+		var planetNodes []treeNode
+		for _, p := range o.Planets {
+			planet := planetLookup[p.PlanetID]
+			n := treeNode{name: fmt.Sprintf("%s - %s", planet.Name, typeLookup[planet.TypeID].Name)}
+			for _, id := range p.MoonIDs {
+				n.children = append(n.children, treeNode{name: moonLookup[id].Name})
+			}
+			for _, id := range p.AsteroidBeltIDs {
+				n.children = append(n.children, treeNode{name: beltLookup[id].Name})
+			}
+			planetNodes = append(planetNodes, n)
+		}
+		var stargateNodes []treeNode
+		for _, id := range o.StargateIDs {
+			stargateNodes = append(stargateNodes, treeNode{name: stargateLookup[id].Name})
+		}
+		var stationNodes []treeNode
+		for _, id := range o.StationIDs {
+			stationNodes = append(stationNodes, treeNode{name: stationLookup[id].Name})
+		}
+		star := starLookup[o.StarID]
+		nodes := []treeNode{{name: star.Name}}
+		if o.StarID == 0 {
+			nodes = nil
+		}
+		nodes = append(nodes,
+			treeNode{name: fmt.Sprintf("Planets (%d)", len(planetNodes)), children: planetNodes},
+			treeNode{name: fmt.Sprintf("Stargates (%d)", len(stargateNodes)), children: stargateNodes},
+			treeNode{name: fmt.Sprintf("Stations (%d)", len(stationNodes)), children: stationNodes},
+		)
+		contents[o.ID()] = solarSystemContents{star: star, tree: renderTree(nodes)}
+	}
+	return contents, nil
+}
+
+type location struct {
+	solarSystem   EveSolarSystem
+	constellation EveConstellation
+	region        EveRegion
+}
+
+// fetchLocations fetches and returns the locations of solar systems by their ID.
+func (a App) fetchLocations(solarSystemIDs []int32) (map[int32]location, error) {
+	systems, err := a.fetchSolarSystems(solarSystemIDs)
 	if err != nil {
 		return nil, err
 	}
 	constellationIDs := make([]int32, 0)
-	for _, o := range types {
+	for _, o := range systems {
 		constellationIDs = append(constellationIDs, o.ConstellationID)
 	}
 	constellations, err := a.fetchConstellations(constellationIDs)
@@ -819,16 +1781,16 @@ func (a App) buildSolarSystemTable(ids []int32) (*tablewriter.Table, error) {
 		return nil, err
 	}
 	regionLookup := makeLookupMap(regions)
-	t := makeSortedTable(
-		a,
-		[]string{"ID", "Name", "ConstellationID", "ConstellationName", "RegionID", "RegionName", "Security"},
-		types,
-		func(o EveSolarSystem) []any {
-			constellation := constellationLookup[o.ConstellationID]
-			region := regionLookup[constellation.RegionID]
-			return []any{o.ID(), o.Name, constellation.ConstellationID, constellation.Name, region.RegionID, region.Name, o.Security}
-		})
-	return t, nil
+	locations := make(map[int32]location)
+	for _, o := range systems {
+		constellation := constellationLookup[o.ConstellationID]
+		locations[o.ID()] = location{
+			solarSystem:   o,
+			constellation: constellation,
+			region:        regionLookup[constellation.RegionID],
+		}
+	}
+	return locations, nil
 }
 
 func (a App) fetchSolarSystems(ids []int32) ([]EveSolarSystem, error) {
@@ -839,11 +1801,24 @@ func (a App) fetchSolarSystems(ids []int32) ([]EveSolarSystem, error) {
 			return a.esiClient.ESI.UniverseApi.GetUniverseSystemsSystemId(context.Background(), id, nil)
 		},
 		func(id int32, x esi.GetUniverseSystemsSystemIdOk) EveSolarSystem {
+			var planets []EveSolarSystemPlanet
+			for _, p := range x.Planets {
+				planets = append(planets, EveSolarSystemPlanet{
+					AsteroidBeltIDs: p.AsteroidBelts,
+					MoonIDs:         p.Moons,
+					PlanetID:        p.PlanetId,
+				})
+			}
 			return EveSolarSystem{
 				ConstellationID: x.ConstellationId,
 				Name:            x.Name,
+				Planets:         planets,
+				Position:        position{X: x.Position.X, Y: x.Position.Y, Z: x.Position.Z},
 				Security:        x.SecurityStatus,
 				SolarSystemID:   id,
+				StarID:          x.StarId,
+				StargateIDs:     x.Stargates,
+				StationIDs:      x.Stations,
 				Timestamp:       now(),
 			}
 		},
@@ -852,7 +1827,237 @@ func (a App) fetchSolarSystems(ids []int32) ([]EveSolarSystem, error) {
 	return oo, err
 }
 
-func (a App) buildConstellationTable(ids []int32) (*tablewriter.Table, error) {
+func (a App) buildPlanetTable(ids []int32) (*table, error) {
+	planets, err := a.fetchPlanets(ids)
+	if err != nil {
+		return nil, err
+	}
+	var systemIDs, typeIDs []int32
+	for _, o := range planets {
+		systemIDs = append(systemIDs, o.SolarSystemID)
+		typeIDs = append(typeIDs, o.TypeID)
+	}
+	locations, err := a.fetchLocations(systemIDs)
+	if err != nil {
+		return nil, err
+	}
+	types, err := a.fetchTypes(typeIDs)
+	if err != nil {
+		return nil, err
+	}
+	typeLookup := makeLookupMap(types)
+	t := makeSortedTable(
+		a,
+		[]string{"ID", "Name", "TypeID", "TypeName", "SolarSystemID", "SolarSystemName", "RegionName", "Security"},
+		planets,
+		func(o EvePlanet) []any {
+			l := locations[o.SolarSystemID]
+			return []any{o.ID(), o.Name, o.TypeID, typeLookup[o.TypeID].Name, o.SolarSystemID, l.solarSystem.Name, l.region.Name, formatSecurity(l.solarSystem.DisplaySecurity())}
+		})
+	return t, nil
+}
+
+func (a App) buildMoonTable(ids []int32) (*table, error) {
+	moons, err := a.fetchMoons(ids)
+	if err != nil {
+		return nil, err
+	}
+	var systemIDs []int32
+	for _, o := range moons {
+		systemIDs = append(systemIDs, o.SolarSystemID)
+	}
+	locations, err := a.fetchLocations(systemIDs)
+	if err != nil {
+		return nil, err
+	}
+	t := makeSortedTable(
+		a,
+		[]string{"ID", "Name", "SolarSystemID", "SolarSystemName", "RegionName", "Security"},
+		moons,
+		func(o EveMoon) []any {
+			l := locations[o.SolarSystemID]
+			return []any{o.ID(), o.Name, o.SolarSystemID, l.solarSystem.Name, l.region.Name, formatSecurity(l.solarSystem.DisplaySecurity())}
+		})
+	return t, nil
+}
+
+func (a App) buildAsteroidBeltTable(ids []int32) (*table, error) {
+	belts, err := a.fetchAsteroidBelts(ids)
+	if err != nil {
+		return nil, err
+	}
+	var systemIDs []int32
+	for _, o := range belts {
+		systemIDs = append(systemIDs, o.SolarSystemID)
+	}
+	locations, err := a.fetchLocations(systemIDs)
+	if err != nil {
+		return nil, err
+	}
+	t := makeSortedTable(
+		a,
+		[]string{"ID", "Name", "SolarSystemID", "SolarSystemName", "RegionName", "Security"},
+		belts,
+		func(o EveAsteroidBelt) []any {
+			l := locations[o.SolarSystemID]
+			return []any{o.ID(), o.Name, o.SolarSystemID, l.solarSystem.Name, l.region.Name, formatSecurity(l.solarSystem.DisplaySecurity())}
+		})
+	return t, nil
+}
+
+func (a App) buildStargateTable(ids []int32) (*table, error) {
+	stargates, err := a.fetchStargates(ids)
+	if err != nil {
+		return nil, err
+	}
+	var systemIDs, typeIDs []int32
+	for _, o := range stargates {
+		systemIDs = append(systemIDs, o.SolarSystemID, o.DestinationSolarSystemID)
+		typeIDs = append(typeIDs, o.TypeID)
+	}
+	locations, err := a.fetchLocations(systemIDs)
+	if err != nil {
+		return nil, err
+	}
+	types, err := a.fetchTypes(typeIDs)
+	if err != nil {
+		return nil, err
+	}
+	typeLookup := makeLookupMap(types)
+	t := makeSortedTable(
+		a,
+		[]string{"ID", "Name", "TypeName", "SolarSystemID", "SolarSystemName", "RegionName", "DestinationSolarSystemID", "DestinationSolarSystemName", "DestinationRegionName", "DestinationStargateID"},
+		stargates,
+		func(o EveStargate) []any {
+			l := locations[o.SolarSystemID]
+			d := locations[o.DestinationSolarSystemID]
+			return []any{
+				o.ID(),
+				o.Name,
+				typeLookup[o.TypeID].Name,
+				o.SolarSystemID,
+				l.solarSystem.Name,
+				l.region.Name,
+				o.DestinationSolarSystemID,
+				d.solarSystem.Name,
+				d.region.Name,
+				o.DestinationStargateID,
+			}
+		})
+	return t, nil
+}
+
+func (a App) fetchStars(ids []int32) ([]EveStar, error) {
+	oo, _, err := fetchObjects(
+		ids,
+		a.st.ListFreshEveStarByID,
+		func(id int32) (esi.GetUniverseStarsStarIdOk, *http.Response, error) {
+			return a.esiClient.ESI.UniverseApi.GetUniverseStarsStarId(context.Background(), id, nil)
+		},
+		func(id int32, x esi.GetUniverseStarsStarIdOk) EveStar {
+			return EveStar{
+				Age:           x.Age,
+				Luminosity:    x.Luminosity,
+				Name:          x.Name,
+				Radius:        x.Radius,
+				SolarSystemID: x.SolarSystemId,
+				SpectralClass: x.SpectralClass,
+				StarID:        id,
+				Temperature:   x.Temperature,
+				Timestamp:     now(),
+				TypeID:        x.TypeId,
+			}
+		},
+		a.st.UpdateOrCreateEveStar,
+	)
+	return oo, err
+}
+
+func (a App) fetchPlanets(ids []int32) ([]EvePlanet, error) {
+	oo, _, err := fetchObjects(
+		ids,
+		a.st.ListFreshEvePlanetByID,
+		func(id int32) (esi.GetUniversePlanetsPlanetIdOk, *http.Response, error) {
+			return a.esiClient.ESI.UniverseApi.GetUniversePlanetsPlanetId(context.Background(), id, nil)
+		},
+		func(id int32, x esi.GetUniversePlanetsPlanetIdOk) EvePlanet {
+			return EvePlanet{
+				Name:          x.Name,
+				PlanetID:      id,
+				SolarSystemID: x.SystemId,
+				Timestamp:     now(),
+				TypeID:        x.TypeId,
+			}
+		},
+		a.st.UpdateOrCreateEvePlanet,
+	)
+	return oo, err
+}
+
+func (a App) fetchMoons(ids []int32) ([]EveMoon, error) {
+	oo, _, err := fetchObjects(
+		ids,
+		a.st.ListFreshEveMoonByID,
+		func(id int32) (esi.GetUniverseMoonsMoonIdOk, *http.Response, error) {
+			return a.esiClient.ESI.UniverseApi.GetUniverseMoonsMoonId(context.Background(), id, nil)
+		},
+		func(id int32, x esi.GetUniverseMoonsMoonIdOk) EveMoon {
+			return EveMoon{
+				MoonID:        id,
+				Name:          x.Name,
+				SolarSystemID: x.SystemId,
+				Timestamp:     now(),
+			}
+		},
+		a.st.UpdateOrCreateEveMoon,
+	)
+	return oo, err
+}
+
+func (a App) fetchAsteroidBelts(ids []int32) ([]EveAsteroidBelt, error) {
+	oo, _, err := fetchObjects(
+		ids,
+		a.st.ListFreshEveAsteroidBeltByID,
+		func(id int32) (esi.GetUniverseAsteroidBeltsAsteroidBeltIdOk, *http.Response, error) {
+			return a.esiClient.ESI.UniverseApi.GetUniverseAsteroidBeltsAsteroidBeltId(context.Background(), id, nil)
+		},
+		func(id int32, x esi.GetUniverseAsteroidBeltsAsteroidBeltIdOk) EveAsteroidBelt {
+			return EveAsteroidBelt{
+				AsteroidBeltID: id,
+				Name:           x.Name,
+				SolarSystemID:  x.SystemId,
+				Timestamp:      now(),
+			}
+		},
+		a.st.UpdateOrCreateEveAsteroidBelt,
+	)
+	return oo, err
+}
+
+func (a App) fetchStargates(ids []int32) ([]EveStargate, error) {
+	oo, _, err := fetchObjects(
+		ids,
+		a.st.ListFreshEveStargateByID,
+		func(id int32) (esi.GetUniverseStargatesStargateIdOk, *http.Response, error) {
+			return a.esiClient.ESI.UniverseApi.GetUniverseStargatesStargateId(context.Background(), id, nil)
+		},
+		func(id int32, x esi.GetUniverseStargatesStargateIdOk) EveStargate {
+			return EveStargate{
+				DestinationSolarSystemID: x.Destination.SystemId,
+				DestinationStargateID:    x.Destination.StargateId,
+				Name:                     x.Name,
+				SolarSystemID:            x.SystemId,
+				StargateID:               id,
+				Timestamp:                now(),
+				TypeID:                   x.TypeId,
+			}
+		},
+		a.st.UpdateOrCreateEveStargate,
+	)
+	return oo, err
+}
+
+func (a App) buildConstellationTable(ids []int32) (*table, error) {
 	constellations, err := a.fetchConstellations(ids)
 	if err != nil {
 		return nil, err
@@ -868,10 +2073,10 @@ func (a App) buildConstellationTable(ids []int32) (*tablewriter.Table, error) {
 	regionLookup := makeLookupMap(regions)
 	t := makeSortedTable(
 		a,
-		[]string{"ID", "Name", "RegionID", "RegionName"},
+		[]string{"ID", "Name", "RegionID", "RegionName", "Systems"},
 		constellations,
 		func(o EveConstellation) []any {
-			return []any{o.ID(), o.Name, o.RegionID, regionLookup[o.RegionID].Name}
+			return []any{o.ID(), o.Name, o.RegionID, regionLookup[o.RegionID].Name, len(o.SolarSystemIDs)}
 		})
 	return t, nil
 }
@@ -888,6 +2093,7 @@ func (a App) fetchConstellations(ids []int32) ([]EveConstellation, error) {
 				ConstellationID: id,
 				RegionID:        x.RegionId,
 				Name:            x.Name,
+				SolarSystemIDs:  append([]int32{}, x.Systems...),
 				Timestamp:       now(),
 			}
 		},
@@ -896,22 +2102,96 @@ func (a App) fetchConstellations(ids []int32) ([]EveConstellation, error) {
 	return oo, err
 }
 
-func (a App) buildRegionTable(ids []int32) (*tablewriter.Table, error) {
+func (a App) buildRegionTable(ids []int32) (*table, error) {
 	regions, err := a.fetchRegions(ids)
 	if err != nil {
 		return nil, err
 	}
 	t := makeSortedTable(
 		a,
-		[]string{"ID", "Name"},
+		[]string{"ID", "Name", "Constellations"},
 		regions,
 		func(o EveRegion) []any {
-			return []any{o.ID(), o.Name}
+			return []any{o.ID(), o.Name, len(o.ConstellationIDs)}
 		},
 	)
 	return t, nil
 }
 
+// expandRegions returns a result for each region listing its constellations and solar systems.
+func (a App) expandRegions(ids []int32) ([]result, error) {
+	regions, err := a.fetchRegions(ids)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(regions, func(x, y EveRegion) int {
+		return strings.Compare(x.Name, y.Name)
+	})
+	var results []result
+	for _, o := range regions {
+		t, err := a.buildMemberSystemsTable(o.ConstellationIDs)
+		if err != nil {
+			return nil, err
+		}
+		title := fmt.Sprintf("Solar systems in %s (%d constellations, %d systems)", o.Name, len(o.ConstellationIDs), len(t.rows))
+		results = append(results, result{title, t})
+	}
+	return results, nil
+}
+
+// expandConstellations returns a result for each constellation listing its solar systems.
+func (a App) expandConstellations(ids []int32) ([]result, error) {
+	constellations, err := a.fetchConstellations(ids)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(constellations, func(x, y EveConstellation) int {
+		return strings.Compare(x.Name, y.Name)
+	})
+	var results []result
+	for _, o := range constellations {
+		t, err := a.buildMemberSystemsTable([]int32{o.ID()})
+		if err != nil {
+			return nil, err
+		}
+		title := fmt.Sprintf("Solar systems in %s (%d systems)", o.Name, len(t.rows))
+		results = append(results, result{title, t})
+	}
+	return results, nil
+}
+
+// buildMemberSystemsTable returns a table with the solar systems of constellations
+// sorted by constellation and solar system name.
+func (a App) buildMemberSystemsTable(constellationIDs []int32) (*table, error) {
+	constellations, err := a.fetchConstellations(constellationIDs)
+	if err != nil {
+		return nil, err
+	}
+	var systemIDs []int32
+	for _, o := range constellations {
+		systemIDs = append(systemIDs, o.SolarSystemIDs...)
+	}
+	systems, err := a.fetchSolarSystems(systemIDs)
+	if err != nil {
+		return nil, err
+	}
+	constellationLookup := makeLookupMap(constellations)
+	slices.SortFunc(systems, func(x, y EveSolarSystem) int {
+		return cmp.Or(
+			strings.Compare(constellationLookup[x.ConstellationID].Name, constellationLookup[y.ConstellationID].Name),
+			strings.Compare(x.Name, y.Name),
+		)
+	})
+	t := &table{headers: []string{"ConstellationID", "ConstellationName", "SolarSystemID", "SolarSystemName", "Security"}}
+	for _, o := range systems {
+		t.rows = append(t.rows, tableRow{
+			values: []any{o.ConstellationID, constellationLookup[o.ConstellationID].Name, o.ID(), o.Name, formatSecurity(o.DisplaySecurity())},
+			color:  a.highlightColor(o.ID()),
+		})
+	}
+	return t, nil
+}
+
 func (a App) fetchRegions(ids []int32) ([]EveRegion, error) {
 	oo, _, err := fetchObjects(
 		ids,
@@ -921,9 +2201,10 @@ func (a App) fetchRegions(ids []int32) ([]EveRegion, error) {
 		},
 		func(id int32, x esi.GetUniverseRegionsRegionIdOk) EveRegion {
 			return EveRegion{
-				RegionID:  id,
-				Name:      x.Name,
-				Timestamp: now(),
+				ConstellationIDs: append([]int32{}, x.Constellations...),
+				RegionID:         id,
+				Name:             x.Name,
+				Timestamp:        now(),
 			}
 		},
 		a.st.UpdateOrCreateEveRegion,
@@ -931,6 +2212,57 @@ func (a App) fetchRegions(ids []int32) ([]EveRegion, error) {
 	return oo, err
 }
 
+// formatDate returns a date in ISO format or an empty string for zero dates.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
+
+// formatPercent returns a fraction as percentage with one decimal, e.g. 0.05 becomes "5.0%".
+func formatPercent(v float32) string {
+	return fmt.Sprintf("%.1f%%", v*100)
+}
+
+// formatSecurity returns a security status rounded to one decimal.
+func formatSecurity(v float32) string {
+	if v < 0 && v > -0.05 {
+		v = 0 // avoid showing "-0.0"
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+var (
+	reLineBreak = regexp.MustCompile(`(?i)<br\s*/?>`)
+	reTag       = regexp.MustCompile(`<[^>]*>`)
+)
+
+// formatNumber returns v with two decimals and thousands separators.
+func formatNumber(v float64) string {
+	return message.NewPrinter(language.English).Sprintf("%.2f", v)
+}
+
+// formatFloat returns v without trailing zeros.
+func formatFloat(v float32) string {
+	return strconv.FormatFloat(float64(v), 'f', -1, 32)
+}
+
+// stripTags returns s with all HTML tags removed and line breaks converted to new lines.
+func stripTags(s string) string {
+	s = reLineBreak.ReplaceAllString(s, "\n")
+	s = reTag.ReplaceAllString(s, "")
+	return html.UnescapeString(strings.TrimSpace(s))
+}
+
+// entityDisplayName returns the name of an entity or it's ID when the name is not known.
+func entityDisplayName(e EveEntity) string {
+	if e.Name == "" {
+		return fmt.Sprint(e.ID())
+	}
+	return e.Name
+}
+
 func idOrEmpty(id int32) string {
 	if id == 0 {
 		return ""
@@ -969,6 +2301,7 @@ func fetchObjects[X any, Y EveObject](ids []int32, fetcherStorage func([]int32)
 	objsRemote := make([]Y, len(missing))
 	invalidIDs := make([]int32, len(missing))
 	g := new(errgroup.Group)
+	g.SetLimit(maxConcurrentRequests)
 	for i, id := range missing {
 		g.Go(func() error {
 			x, r, err := fetcherAPI(id)
@@ -1002,29 +2335,9 @@ func fetchObjects[X any, Y EveObject](ids []int32, fetcherStorage func([]int32)
 	return objs, invalid2, nil
 }
 
-func makeSortedTable[T EveObject](a App, headers []string, objs []T, makeRow func(T) []any) *tablewriter.Table {
-	slices.SortFunc(objs, func(a, b T) int {
-		return cmp.Compare(a.ID(), b.ID())
-	})
-	rows := make([][]any, 0)
-	for _, o := range objs {
-		rows = append(rows, makeRow(o))
-	}
-	t := tablewriter.NewTable(a.out,
-		tablewriter.WithRenderer(renderer.NewBlueprint(tw.Rendition{
-			Settings: tw.Settings{Separators: tw.Separators{BetweenRows: tw.On}},
-		})),
-		tablewriter.WithConfig(tablewriter.Config{
-			MaxWidth: a.MaxWidth,
-			Row: tw.CellConfig{
-				Formatting: tw.CellFormatting{AutoWrap: tw.WrapNormal},
-				Alignment:  tw.CellAlignment{Global: tw.AlignLeft}, // Left-align rows
-			},
-		}),
-	)
-	t.Header(headers)
-	t.Bulk(rows)
-	return t
+// colorize returns s wrapped in ANSI escape codes for the given color.
+func colorize(s, color string) string {
+	return color + s + colorReset
 }
 
 func now() time.Time {
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/antihax/goesi"
 	"github.com/jarcoal/httpmock"
@@ -42,6 +43,11 @@ func TestApp_Run(t *testing.T) {
 		{0, "#System", "inventory_type"},
 		{1000080, "Ministry of War", "corporation"},
 		{1000023, "Expert Distribution", "corporation"},
+		{98699354, "Rope Holding", "corporation"},
+		{2119493499, "Rope Creator", "character"},
+		{1559150123, "Baltrom", "character"},
+		{30000145, "New Caldari", "solar_system"},
+		{60015111, "Jita IV - Moon 4 - Caldari Navy Assembly Plant", "station"},
 	}
 	entities := slices.Concat(primaryEntities, secondaryEntities)
 	entityLookup := make(map[int32]entity)
@@ -114,6 +120,11 @@ func TestApp_Run(t *testing.T) {
 		}
 		return httpmock.NewJsonResponse(200, r)
 	}
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/sovereignty/(map|structures)/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{}),
+	)
 	httpmock.RegisterResponder(
 		"GET",
 		`=~^https://esi\.evetech\.net/v\d+/alliances/(\d+)/`,
@@ -229,6 +240,26 @@ func TestApp_Run(t *testing.T) {
 			return makeObjectEndpoint(req, data)
 		},
 	)
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/characters/affiliation/`,
+		func(req *http.Request) (*http.Response, error) {
+			data := map[int32]map[string]any{
+				93330670: {"alliance_id": 99013305, "character_id": 93330670, "corporation_id": 98267621},
+			}
+			var ids []int32
+			if err := json.NewDecoder(req.Body).Decode(&ids); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			var results []map[string]any
+			for _, id := range ids {
+				if r, found := data[id]; found {
+					results = append(results, r)
+				}
+			}
+			return httpmock.NewJsonResponse(200, results)
+		},
+	)
 	httpmock.RegisterResponder(
 		"GET",
 		`=~^https://esi\.evetech\.net/v\d+/universe/constellations/(\d+)/`,
@@ -274,6 +305,22 @@ func TestApp_Run(t *testing.T) {
 			},
 		}),
 	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/races/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"alliance_id": 500001, "description": "", "name": "Caldari", "race_id": 1},
+			{"alliance_id": 500003, "description": "", "name": "Amarr", "race_id": 4},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/bloodlines/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"bloodline_id": 1, "corporation_id": 1000006, "name": "Deteis", "race_id": 1},
+			{"bloodline_id": 6, "corporation_id": 1000066, "name": "Ni-Kunni", "race_id": 4},
+		}),
+	)
 	httpmock.RegisterResponder(
 		"GET",
 		`=~^https://esi\.evetech\.net/v\d+/universe/groups/(\d+)/`,
@@ -686,6 +733,154 @@ func TestApp_Run(t *testing.T) {
 		assert.Contains(t, got, "INVALID")
 	})
 
+	t.Run("can show station with details", func(t *testing.T) {
+		st.Clear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Detail = true
+		err := a.Run([]string{fmt.Sprint(60002590)})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Solar System Name\s+│ Amamake`, got)
+		assert.Regexp(t, `Constellation Name\s+│ Hed`, got)
+		assert.Regexp(t, `Region Name\s+│ Heimatar`, got)
+		assert.Regexp(t, `Owner Name\s+│ Expert Distribution`, got)
+	})
+
+	t.Run("can show character with details", func(t *testing.T) {
+		st.Clear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Detail = true
+		err := a.Run([]string{fmt.Sprint(93330670)})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Birthday\s+│ 2013-05-12`, got)
+		assert.Regexp(t, `Security\s+│ -10.0`, got)
+		assert.Regexp(t, `Race\s+│ Caldari`, got)
+		assert.Regexp(t, `Bloodline\s+│ Deteis`, got)
+		assert.Regexp(t, `Gender\s+│ male`, got)
+		assert.Regexp(t, `Description\s+│ These days I mostly "play EVE"`, got)
+	})
+
+	t.Run("can refresh affiliation of stale characters in bulk", func(t *testing.T) {
+		st.Clear()
+		err := st.UpdateOrCreateEveCharacter([]EveCharacter{{
+			CharacterID:      93330670,
+			CorporationID:    1000080,
+			DetailsTimestamp: time.Now().UTC().Add(-48 * time.Hour),
+			Name:             "Erik Kalkoken (cached)",
+			Timestamp:        time.Now().UTC().Add(-48 * time.Hour),
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err = a.Run([]string{fmt.Sprint(93330670)})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Erik Kalkoken \(cached\)\s+│ 98267621\s+│ The Congregation\s+│ 99013305`, got)
+		oo, _, err := st.ListFreshEveCharacterByID([]int32{93330670})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Len(t, oo, 1)
+	})
+
+	t.Run("can refetch characters with stale details", func(t *testing.T) {
+		st.Clear()
+		err := st.UpdateOrCreateEveCharacter([]EveCharacter{{
+			CharacterID:   93330670,
+			CorporationID: 1000080,
+			Name:          "Erik Kalkoken (cached)",
+			Timestamp:     time.Now().UTC().Add(-48 * time.Hour),
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err = a.Run([]string{fmt.Sprint(93330670)})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.NotContains(t, got, "(cached)")
+		assert.Regexp(t, `Erik Kalkoken\s+│ 98267621\s+│ The Congregation\s+│ 99013305.+│ -10.0`, got)
+		oo, _, err := st.ListFreshEveCharacterByID([]int32{93330670})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		if assert.Len(t, oo, 1) {
+			assert.False(t, oo[0].Birthday.IsZero())
+		}
+	})
+
+	t.Run("can show faction with details", func(t *testing.T) {
+		st.Clear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Detail = true
+		err := a.Run([]string{fmt.Sprint(500001)})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Home Solar System Name\s+│ New Caldari`, got)
+		assert.Regexp(t, `Stations\s+│ 1527`, got)
+		assert.Regexp(t, `Station Systems\s+│ 528`, got)
+		assert.Regexp(t, `Unique\s+│ true`, got)
+		assert.Regexp(t, `Description\s+│ The Caldari State is ruled`, got)
+	})
+
+	t.Run("can show alliance with details", func(t *testing.T) {
+		st.Clear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Detail = true
+		err := a.Run([]string{fmt.Sprint(99013305)})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Date Founded\s+│ 2024-06-02`, got)
+		assert.Regexp(t, `Executor Corporation Name\s+│ Rope Holding`, got)
+		assert.Regexp(t, `Creator Name\s+│ Rope Creator`, got)
+	})
+
+	t.Run("can show corporation with details", func(t *testing.T) {
+		st.Clear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Detail = true
+		err := a.Run([]string{fmt.Sprint(98267621)})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Ceo Name\s+│ Baltrom`, got)
+		assert.Regexp(t, `Creator Name\s+│ Baltrom`, got)
+		assert.Regexp(t, `Date Founded\s+│ 2013-11-26`, got)
+		assert.Regexp(t, `Home Station Name\s+│ Jita IV - Moon 4`, got)
+		assert.Regexp(t, `Tax Rate\s+│ 5.0%`, got)
+		assert.Regexp(t, `URL\s+│ https://www.rabis.space/home`, got)
+		assert.Regexp(t, `War Eligible\s+│ true`, got)
+	})
+
 	t.Run("should ignore ID 0", func(t *testing.T) {
 		st.Clear()
 		var buf bytes.Buffer
@@ -701,6 +896,389 @@ func TestApp_Run(t *testing.T) {
 	})
 }
 
+// Celestials and stargates are not known to the names endpoint and resolved through their own endpoints.
+func TestApp_RunSolarSystemObjects(t *testing.T) {
+	entities := []entity{
+		{30000001, "Tanoo", "solar_system"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint(entities),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/systems/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			30000001: {
+				"constellation_id": 20000001,
+				"name":             "Tanoo",
+				"planets": []map[string]any{
+					{"planet_id": 40000002, "asteroid_belts": []int{40000003}, "moons": []int{40000004, 40000005}},
+					{"planet_id": 40000006},
+				},
+				"security_status": 0.858324,
+				"star_id":         40000001,
+				"stargates":       []int{50000056},
+				"stations":        []int{60012526},
+			},
+			30000003: {"constellation_id": 20000001, "name": "Akpivem", "security_status": 0.846853},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/constellations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			20000001: {"name": "San Matar", "region_id": 10000001},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/regions/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			10000001: {"name": "Derelik"},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/sovereignty/(map|structures)/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/stars/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			40000001: {"name": "Tanoo - Star", "spectral_class": "K7 V", "solar_system_id": 30000001, "type_id": 45036},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/planets/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			40000002: {"name": "Tanoo I", "planet_id": 40000002, "system_id": 30000001, "type_id": 11},
+			40000006: {"name": "Tanoo II", "planet_id": 40000006, "system_id": 30000001, "type_id": 11},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/moons/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			40000004: {"name": "Tanoo I - Moon 1", "moon_id": 40000004, "system_id": 30000001},
+			40000005: {"name": "Tanoo I - Moon 2", "moon_id": 40000005, "system_id": 30000001},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/asteroid_belts/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			40000003: {"name": "Tanoo I - Asteroid Belt 1", "system_id": 30000001},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/stargates/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			50000056: {
+				"destination": map[string]any{"stargate_id": 50000057, "system_id": 30000003},
+				"name":        "Stargate (Akpivem)",
+				"stargate_id": 50000056,
+				"system_id":   30000001,
+				"type_id":     29624,
+			},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/stations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			60012526: {"name": "Tanoo V - Moon 1 - Ammatar Consulate Bureau", "owner": 1000126, "system_id": 30000001, "type_id": 2502},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/types/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			11:    {"name": "Planet (Temperate)", "group_id": 7, "published": true},
+			29624: {"name": "Stargate (Caldari System)", "group_id": 10, "published": false},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show counts of solar system objects", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Columns = []string{"name", "planets", "moons", "stargates", "stations"}
+		err := a.Run([]string{"30000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Regexp(t, `Tanoo\s+│ 2\s+│ 2\s+│ 1\s+│ 1`, buf.String())
+	})
+	t.Run("can show planets, moons and asteroid belts", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.Run([]string{"40000002", "40000004", "40000003"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Planet:")
+		assert.Regexp(t, `40000002\s+│ Tanoo I\s+│ 11\s+│ Planet \(Temperate\)\s+│ 30000001\s+│ Tanoo\s+│ Derelik\s+│ 0.9`, got)
+		assert.Contains(t, got, "Moon:")
+		assert.Regexp(t, `40000004\s+│ Tanoo I - Moon 1\s+│ 30000001\s+│ Tanoo`, got)
+		assert.Contains(t, got, "Asteroid Belt:")
+		assert.Regexp(t, `40000003\s+│ Tanoo I - Asteroid Belt 1\s+│ 30000001\s+│ Tanoo`, got)
+		assert.NotContains(t, got, "INVALID")
+	})
+	t.Run("can show stargates with destination", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Detail = true
+		err := a.Run([]string{"50000056"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Stargate:")
+		assert.Regexp(t, `Solar System Name\s+│ Tanoo`, got)
+		assert.Regexp(t, `Destination Solar System Name\s+│ Akpivem`, got)
+		assert.Regexp(t, `Destination Stargate ID\s+│ 50000057`, got)
+	})
+	t.Run("can limit results to a celestial category", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.EntityCategory = CategoryMoon
+		err := a.Run([]string{"40000002", "40000005"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Tanoo I - Moon 2")
+		assert.NotContains(t, got, "Planet:")
+	})
+	t.Run("can show solar system contents as tree", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Detail = true
+		err := a.Run([]string{"30000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Star\s+│ Tanoo - Star`, got)
+		assert.Regexp(t, `Spectral Class\s+│ K7 V`, got)
+		assert.Regexp(t, `Asteroid Belts\s+│ 1`, got)
+		assert.Contains(t, got, "Planets (2)")
+		assert.Contains(t, got, "├─ Tanoo I - Planet (Temperate)")
+		assert.Contains(t, got, "│  ├─ Tanoo I - Moon 1")
+		assert.Contains(t, got, "│  └─ Tanoo I - Asteroid Belt 1")
+		assert.Contains(t, got, "└─ Tanoo II - Planet (Temperate)")
+		assert.Contains(t, got, "└─ Stargate (Akpivem)")
+		assert.Contains(t, got, "└─ Tanoo V - Moon 1 - Ammatar Consulate Bureau")
+	})
+}
+
+func TestApp_RunExpand(t *testing.T) {
+	entities := []entity{
+		{10000001, "Derelik", "region"},
+		{20000001, "San Matar", "constellation"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint(entities),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/regions/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			10000001: {"name": "Derelik", "constellations": []int{20000001, 20000002}},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/constellations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			20000001: {"name": "San Matar", "region_id": 10000001, "systems": []int{30000002, 30000001}},
+			20000002: {"name": "Kalangin", "region_id": 10000001, "systems": []int{30000008}},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/systems/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			30000001: {"constellation_id": 20000001, "name": "Tanoo", "security_status": 0.858324},
+			30000002: {"constellation_id": 20000001, "name": "Lashesih", "security_status": 0.751146},
+			30000008: {"constellation_id": 20000002, "name": "Sasta", "security_status": 0.379345},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can list constellations and systems of a region", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Expand = true
+		err := a.Run([]string{"10000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `10000001\s+│ Derelik\s+│ 2`, got)
+		assert.Contains(t, got, "Solar systems in Derelik (2 constellations, 3 systems):")
+		assert.Regexp(t, `(?s)Kalangin\s+│ 30000008\s+│ Sasta\s+│ 0.4.+San Matar\s+│ 30000002\s+│ Lashesih\s+│ 0.8.+San Matar\s+│ 30000001\s+│ Tanoo\s+│ 0.9`, got)
+	})
+	t.Run("can list systems of a constellation", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Expand = true
+		err := a.Run([]string{"20000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Solar systems in San Matar (2 systems):")
+		assert.NotContains(t, got, "Sasta")
+	})
+	t.Run("should not list systems without expand", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.Run([]string{"10000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.NotContains(t, buf.String(), "Solar systems in")
+	})
+}
+
+func TestApp_RunTypes(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/types/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			603: {
+				"capacity":    150,
+				"description": "This is the <b>Merlin</b>.",
+				"dogma_attributes": []map[string]any{
+					{"attribute_id": 9, "value": 393},
+					{"attribute_id": 263, "value": 470.5},
+					{"attribute_id": 1000, "value": 1},
+				},
+				"dogma_effects":   []map[string]any{{"effect_id": 511, "is_default": false}},
+				"group_id":        25,
+				"icon_id":         1001,
+				"market_group_id": 61,
+				"mass":            997000,
+				"name":            "Merlin",
+				"packaged_volume": 2500,
+				"published":       true,
+				"volume":          16500,
+			},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/groups/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			25: {"name": "Frigate", "category_id": 6, "published": true},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/categories/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			6: {"name": "Ship", "published": true},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/dogma/attributes/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			9:    {"name": "hp", "display_name": "Structure Hitpoints", "published": true},
+			263:  {"name": "shieldCapacity", "display_name": "", "published": true},
+			1000: {"name": "hidden", "published": false},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/markets/groups/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			4:    {"name": "Ships"},
+			1361: {"name": "Frigates", "parent_group_id": 4},
+			64:   {"name": "Standard Frigates", "parent_group_id": 1361},
+			61:   {"name": "Caldari", "parent_group_id": 64, "types": []int{603}},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint([]entity{{603, "Merlin", "inventory_type"}}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show type with details", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Detail = true
+		err := a.Run([]string{"603"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Mass\s+│ 997000`, got)
+		assert.Regexp(t, `Volume\s+│ 16500`, got)
+		assert.Regexp(t, `Packaged Volume\s+│ 2500`, got)
+		assert.Regexp(t, `Capacity\s+│ 150`, got)
+		assert.Regexp(t, `Market Group ID\s+│ 61`, got)
+		assert.Regexp(t, `Market Group\s+│ Ships > Frigates > Standard Frigates > Caldari`, got)
+		assert.Regexp(t, `Description\s+│ This is the Merlin.`, got)
+		assert.Regexp(t, `(?s)Attributes\s+│ Structure Hitpoints: 393.+shieldCapacity: 470.5`, got)
+		assert.NotContains(t, got, "hidden")
+	})
+	t.Run("should store dogma attributes", func(t *testing.T) {
+		st.MustClear()
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		a.Detail = true
+		err := a.Run([]string{"603"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		oo, missing, err := st.ListFreshEveDogmaAttributeByID([]int32{9, 263, 1000})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Len(t, oo, 3)
+		assert.Empty(t, missing)
+	})
+}
+
 func TestApp_resolveIDsFromAPI(t *testing.T) {
 	entities := []entity{
 		{10000030, "Heimatar", "region"},
@@ -760,6 +1338,46 @@ func TestApp_resolveIDsFromAPI(t *testing.T) {
 	})
 }
 
+func TestApp_resolveNames(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	url := `=~^https://esi\.evetech\.net/v\d+/universe/ids/`
+	httpmock.RegisterResponder(
+		"POST",
+		url,
+		func(req *http.Request) (*http.Response, error) {
+			var names []string
+			if err := json.NewDecoder(req.Body).Decode(&names); err != nil || len(names) > 500 {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			var characters []map[string]any
+			for _, n := range names {
+				var id int
+				fmt.Sscanf(n, "Pilot %d", &id)
+				characters = append(characters, map[string]any{"id": id, "name": n})
+			}
+			return httpmock.NewJsonResponse(200, map[string]any{"characters": characters})
+		},
+	)
+	st := newTestStorage(t)
+	a := NewApp(goesi.NewAPIClient(nil, ""), st, nil)
+	t.Run("can resolve more than 500 names", func(t *testing.T) {
+		var names []string
+		for n := range 1200 {
+			names = append(names, fmt.Sprintf("Pilot %d", 90_000_001+n))
+		}
+		oo, err := a.resolveNames(names)
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Len(t, oo, 1200)
+		for _, o := range oo {
+			assert.Equal(t, CategoryCharacter, o.Category)
+		}
+		assert.Equal(t, 3, httpmock.GetCallCountInfo()["POST "+url])
+	})
+}
+
 // makeUniverseNamesEndpoint creates a stub for the universe names endpoint.
 func makeUniverseNamesEndpoint(entities []entity) func(req *http.Request) (*http.Response, error) {
 	entityLookup := make(map[int32]entity)
@@ -795,3 +1413,35 @@ func extractIDs[T EveObject](oo []T) []int32 {
 	}
 	return got
 }
+
+// makeObjectResponder returns a responder for an object endpoint,
+// where the object ID is the first submatch of the URL.
+func makeObjectResponder(data map[int64]map[string]any) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		id := httpmock.MustGetSubmatchAsInt(req, 1)
+		r, found := data[id]
+		if !found {
+			return httpmock.NewJsonResponse(404, map[string]any{
+				"error": "not found",
+			})
+		}
+		return httpmock.NewJsonResponse(200, r)
+	}
+}
+
+// newTestStorage returns a new storage with a temporary database for tests.
+func newTestStorage(t *testing.T) *Storage {
+	p := filepath.Join(t.TempDir(), "elt.db")
+	db, err := bolt.Open(p, 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+	})
+	st := NewStorage(db)
+	if err := st.Init(); err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// appraisalItem is an inventory type with its quantity from a pasted list.
+type appraisalItem struct {
+	name     string
+	quantity int64
+}
+
+var (
+	rxQuantityPrefix = regexp.MustCompile(`^([\d,.' ]*\d)\s+(.+)$`)      // cargo scan, e.g. "1000 Tritanium"
+	rxQuantitySuffix = regexp.MustCompile(`^(.+?)\s+x\s?([\d,.' ]*\d)$`) // e.g. "Tritanium x 1000"
+)
+
+// parseAppraisalLine returns the item of a line in the inventory copy format ("name<TAB>quantity<TAB>...")
+// or in the cargo scan format ("quantity name"). Lines without a quantity have a quantity of 1.
+func parseAppraisalLine(line string) (appraisalItem, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return appraisalItem{}, false
+	}
+	if name, rest, ok := strings.Cut(line, "\t"); ok {
+		quantity := int64(1)
+		s, _, _ := strings.Cut(rest, "\t")
+		if n, ok := parseQuantity(s); ok {
+			quantity = n
+		}
+		return appraisalItem{name: strings.TrimSpace(name), quantity: quantity}, true
+	}
+	if m := rxQuantityPrefix.FindStringSubmatch(line); m != nil {
+		if n, ok := parseQuantity(m[1]); ok {
+			return appraisalItem{name: m[2], quantity: n}, true
+		}
+	}
+	if m := rxQuantitySuffix.FindStringSubmatch(line); m != nil {
+		if n, ok := parseQuantity(m[2]); ok {
+			return appraisalItem{name: m[1], quantity: n}, true
+		}
+	}
+	return appraisalItem{name: line, quantity: 1}, true
+}
+
+// parseQuantity returns a quantity which can contain thousands separators.
+func parseQuantity(s string) (int64, bool) {
+	s = strings.NewReplacer(",", "", ".", "", "'", "", " ", "").Replace(strings.TrimSpace(s))
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
+// RunAppraise shows the volume and estimated value of pasted items.
+// Items are valued at their average price or at the best buy price on a chosen market.
+func (a App) RunAppraise(lines []string) error {
+	quantities := make(map[string]int64)
+	var names []string
+	for _, line := range lines {
+		item, ok := parseAppraisalLine(line)
+		if !ok {
+			continue
+		}
+		if _, ok := quantities[item.name]; !ok {
+			names = append(names, item.name)
+		}
+		quantities[item.name] += item.quantity
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("the appraise command needs a list of items from stdin")
+	}
+	bar := a.newSpinner(fmt.Sprintf("Appraising %d items ...", len(names)))
+	entities, err := a.resolveValues(nil, names)
+	if err != nil {
+		return err
+	}
+	typeIDs := make(map[string]int32)
+	for _, e := range entities {
+		if e.Category == CategoryInventoryType {
+			typeIDs[e.Name] = e.ID()
+		}
+	}
+	var ids []int32
+	var unknown []string
+	for _, n := range names {
+		if id, ok := typeIDs[n]; ok {
+			ids = append(ids, id)
+		} else {
+			unknown = append(unknown, n)
+		}
+	}
+	types, err := a.fetchTypes(ids)
+	if err != nil {
+		return err
+	}
+	unitPrices, err := a.fetchUnitPrices(ids)
+	if err != nil {
+		return err
+	}
+	type row struct {
+		o        EveType
+		quantity int64
+		volume   float64
+		value    float64
+	}
+	var rows []row
+	var totalVolume, totalValue float64
+	for _, o := range types {
+		quantity := quantities[o.Name]
+		r := row{
+			o:        o,
+			quantity: quantity,
+			volume:   float64(quantity) * float64(cmp.Or(o.PackagedVolume, o.Volume)),
+			value:    float64(quantity) * unitPrices[o.ID()],
+		}
+		totalVolume += r.volume
+		totalValue += r.value
+		rows = append(rows, r)
+	}
+	slices.SortFunc(rows, func(x, y row) int {
+		return cmp.Or(cmp.Compare(y.value, x.value), cmp.Compare(x.o.Name, y.o.Name))
+	})
+	t := &table{headers: []string{"ID", "Name", "Quantity", "Volume", "UnitPrice", "Value"}}
+	for _, r := range rows {
+		t.rows = append(t.rows, tableRow{
+			values: []any{r.o.ID(), r.o.Name, r.quantity, formatNumber(r.volume), formatISK(unitPrices[r.o.ID()]), formatISK(r.value)},
+		})
+	}
+	if bar != nil {
+		bar.Clear()
+	}
+	for _, n := range unknown {
+		fmt.Fprintf(a.out, "Unknown type: %s\n", n)
+	}
+	title := fmt.Sprintf("Appraisal (%d items, %s m3, %s ISK)", len(rows), formatNumber(totalVolume), formatNumber(totalValue))
+	return a.printResults([]result{{title, t}})
+}
+
+// fetchUnitPrices returns the price for one unit of inventory types by their ID.
+// This is the best buy price when a market is chosen or the average price otherwise.
+func (a App) fetchUnitPrices(typeIDs []int32) (map[int32]float64, error) {
+	unitPrices := make(map[int32]float64)
+	if a.Market != "" {
+		m, err := a.resolveMarket(a.Market)
+		if err != nil {
+			return nil, err
+		}
+		prices, err := a.fetchBestPrices(m, typeIDs)
+		if err != nil {
+			return nil, err
+		}
+		for id, p := range prices {
+			unitPrices[id] = p.buy
+		}
+		return unitPrices, nil
+	}
+	prices, err := a.fetchMarketPrices(typeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for id, p := range prices {
+		unitPrices[id] = p.AveragePrice
+	}
+	return unitPrices, nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAppraisalLine(t *testing.T) {
+	cases := []struct {
+		line     string
+		name     string
+		quantity int64
+		ok       bool
+	}{
+		{"Tritanium\t1,000\tMineral\t\t\t10 m3\t3,950.00 ISK", "Tritanium", 1000, true},
+		{"Merlin\t\tFrigate\tShip\t\t16,500 m3", "Merlin", 1, true},
+		{"1000 Tritanium", "Tritanium", 1000, true},
+		{"3 125mm Gatling AutoCannon I", "125mm Gatling AutoCannon I", 3, true},
+		{"125mm Gatling AutoCannon I", "125mm Gatling AutoCannon I", 1, true},
+		{"Pyerite x 2.500", "Pyerite", 2500, true},
+		{"Pyerite x2500", "Pyerite", 2500, true},
+		{"  ", "", 0, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.line, func(t *testing.T) {
+			got, ok := parseAppraisalLine(tc.line)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.name, got.name)
+			assert.Equal(t, tc.quantity, got.quantity)
+		})
+	}
+}
+
+func TestApp_RunAppraise(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/ids/`,
+		func(req *http.Request) (*http.Response, error) {
+			var names []string
+			if err := json.NewDecoder(req.Body).Decode(&names); err != nil || len(names) > 500 {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			return httpmock.NewJsonResponse(200, map[string]any{
+				"inventory_types": []map[string]any{
+					{"id": 34, "name": "Tritanium"},
+					{"id": 603, "name": "Merlin"},
+				},
+				"systems": []map[string]any{{"id": 30000142, "name": "Jita"}},
+			})
+		},
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/types/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			34:  {"name": "Tritanium", "group_id": 18, "published": true, "volume": 0.01},
+			603: {"name": "Merlin", "group_id": 25, "published": true, "volume": 16500, "packaged_volume": 2500},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/markets/prices/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"type_id": 34, "average_price": 4},
+			{"type_id": 603, "average_price": 300000},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/markets/10000002/orders/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"is_buy_order": true, "location_id": 60003760, "price": 3.5},
+			{"is_buy_order": false, "location_id": 60003760, "price": 4.5},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can appraise pasted items", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunAppraise([]string{
+			"Tritanium\t1,000\tMineral",
+			"2 Merlin",
+			"500 Tritanium",
+		})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Appraisal (2 items, 5,015.00 m3, 606,000.00 ISK):")
+		assert.Regexp(t, `(?s)603\s+│ Merlin\s+│ 2\s+│ 5,000.00\s+│ 300,000.00\s+│ 600,000.00.+34\s+│ Tritanium\s+│ 1500\s+│ 15.00\s+│ 4.00\s+│ 6,000.00`, got)
+	})
+	t.Run("should report unknown types", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunAppraise([]string{"10 Tritanium", "Jita", "Unobtanium"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Unknown type: Jita")
+		assert.Contains(t, got, "Unknown type: Unobtanium")
+		assert.Contains(t, got, "Appraisal (1 items, 0.10 m3, 40.00 ISK):")
+	})
+	t.Run("can appraise more than 500 different items", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		lines := []string{"10 Tritanium"}
+		for n := range 600 {
+			lines = append(lines, fmt.Sprintf("Item %d", n))
+		}
+		err := a.RunAppraise(lines)
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Unknown type: Item 599")
+		assert.Contains(t, got, "Appraisal (1 items, 0.10 m3, 40.00 ISK):")
+	})
+	t.Run("can appraise at best buy price of a trade hub", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Market = "jita"
+		err := a.RunAppraise([]string{"1000 Tritanium"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Contains(t, buf.String(), "3,500.00 ISK")
+	})
+	t.Run("should report error when no items are given", func(t *testing.T) {
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		err := a.RunAppraise(nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Kinds of hierarchies which can be browsed
+const (
+	browseMarketGroup = "market-group"
+)
+
+var browseKinds = []string{browseMarketGroup}
+
+// RunBrowse shows the children of a node in a hierarchy.
+// Without an ID it shows the top level nodes.
+func (a App) RunBrowse(args []string) error {
+	if len(args) == 0 || !slices.Contains(browseKinds, args[0]) {
+		return fmt.Errorf("the browse command needs one of these kinds: %s", strings.Join(browseKinds, ", "))
+	}
+	if len(args) > 2 {
+		return fmt.Errorf("the browse command takes at most one ID")
+	}
+	var id int32
+	if len(args) == 2 {
+		x, err := strconv.ParseInt(args[1], 10, 32)
+		if err != nil || x < 1 {
+			return fmt.Errorf("not a valid ID: %s", args[1])
+		}
+		id = int32(x)
+	}
+	bar := a.newSpinner("Fetching market groups ...")
+	results, err := a.browseMarketGroups(id)
+	if err != nil {
+		return err
+	}
+	if bar != nil {
+		bar.Clear()
+	}
+	return a.printResults(results)
+}
+
+// browseMarketGroups returns the child groups and the types of a market group.
+// When the ID is 0 it returns the top level market groups.
+func (a App) browseMarketGroups(id int32) ([]result, error) {
+	ids, _, err := a.esiClient.ESI.MarketApi.GetMarketsGroups(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := a.fetchMarketGroups(ids)
+	if err != nil {
+		return nil, err
+	}
+	childCounts := make(map[int32]int)
+	for _, o := range groups {
+		childCounts[o.ParentGroupID]++
+	}
+	var parent EveMarketGroup
+	title := "Market groups"
+	if id != 0 {
+		i := slices.IndexFunc(groups, func(o EveMarketGroup) bool {
+			return o.ID() == id
+		})
+		if i == -1 {
+			return nil, fmt.Errorf("market group not found: %d", id)
+		}
+		parent = groups[i]
+		paths, err := a.fetchMarketGroupPaths([]int32{id})
+		if err != nil {
+			return nil, err
+		}
+		title = paths[id]
+	}
+	children := slices.DeleteFunc(groups, func(o EveMarketGroup) bool {
+		return o.ParentGroupID != id
+	})
+	slices.SortFunc(children, func(x, y EveMarketGroup) int {
+		return cmp.Or(cmp.Compare(x.Name, y.Name), cmp.Compare(x.ID(), y.ID()))
+	})
+	var results []result
+	if len(children) > 0 || len(parent.TypeIDs) == 0 {
+		t := &table{headers: []string{"ID", "Name", "Groups", "Types"}}
+		for _, o := range children {
+			t.rows = append(t.rows, tableRow{values: []any{o.ID(), o.Name, childCounts[o.ID()], len(o.TypeIDs)}})
+		}
+		results = append(results, result{fmt.Sprintf("%s (%d groups)", title, len(children)), t})
+	}
+	if len(parent.TypeIDs) > 0 {
+		types, err := a.fetchTypes(parent.TypeIDs)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(types, func(x, y EveType) int {
+			return cmp.Or(cmp.Compare(x.Name, y.Name), cmp.Compare(x.ID(), y.ID()))
+		})
+		t := &table{headers: []string{"ID", "Name", "Published"}}
+		for _, o := range types {
+			t.rows = append(t.rows, tableRow{values: []any{o.ID(), o.Name, o.Published}})
+		}
+		results = append(results, result{fmt.Sprintf("%s (%d types)", title, len(types)), t})
+	}
+	return results, nil
+}
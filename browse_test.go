@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_RunBrowse(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/markets/groups/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			4:    {"name": "Ships"},
+			9:    {"name": "Ship Equipment"},
+			1361: {"name": "Frigates", "parent_group_id": 4},
+			391:  {"name": "Cruisers", "parent_group_id": 4},
+			64:   {"name": "Standard Frigates", "parent_group_id": 1361},
+			61:   {"name": "Caldari", "parent_group_id": 64, "types": []int{603, 602}},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/markets/groups/`,
+		httpmock.NewJsonResponderOrPanic(200, []int{4, 9, 1361, 391, 64, 61}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/types/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			602: {"name": "Kestrel", "group_id": 25, "published": true},
+			603: {"name": "Merlin", "group_id": 25, "published": true},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show top level market groups", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunBrowse([]string{"market-group"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Market groups (2 groups):")
+		assert.Regexp(t, `(?s)9\s+│ Ship Equipment\s+│ 0\s+│ 0.+4\s+│ Ships\s+│ 2\s+│ 0`, got)
+		assert.NotContains(t, got, "Frigates")
+	})
+	t.Run("can show child groups of a market group", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunBrowse([]string{"market-group", "4"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Ships (2 groups):")
+		assert.Regexp(t, `(?s)Cruisers.+Frigates\s+│ 1`, got)
+	})
+	t.Run("can show types of a market group", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunBrowse([]string{"market-group", "61"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Ships > Frigates > Standard Frigates > Caldari (2 types):")
+		assert.Regexp(t, `(?s)602\s+│ Kestrel.+603\s+│ Merlin`, got)
+		assert.NotContains(t, got, "groups)")
+	})
+	t.Run("should report unknown market group", func(t *testing.T) {
+		st.MustClear()
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		err := a.RunBrowse([]string{"market-group", "99"})
+		assert.ErrorContains(t, err, "market group not found: 99")
+	})
+	t.Run("should report IDs out of range", func(t *testing.T) {
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		err := a.RunBrowse([]string{"market-group", "4294967300"})
+		assert.ErrorContains(t, err, "not a valid ID: 4294967300")
+	})
+	t.Run("should report invalid kind", func(t *testing.T) {
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		err := a.RunBrowse([]string{"solar-system"})
+		assert.ErrorContains(t, err, "market-group")
+	})
+}
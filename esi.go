@@ -0,0 +1,19 @@
+package main
+
+import (
+	"net/http"
+	"time"
+)
+
+// responseExpires returns the expiry reported in an ESI response
+// or the fallback when it is missing or later than the fallback.
+func responseExpires(r *http.Response, fallback time.Time) time.Time {
+	if r == nil {
+		return fallback
+	}
+	t, err := http.ParseTime(r.Header.Get("Expires"))
+	if err != nil || !t.Before(fallback) {
+		return fallback
+	}
+	return t.UTC()
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseExpires(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	fallback := now.Add(time.Hour)
+	makeResponse := func(expires string) *http.Response {
+		r := &http.Response{Header: make(http.Header)}
+		if expires != "" {
+			r.Header.Set("Expires", expires)
+		}
+		return r
+	}
+	cases := []struct {
+		name string
+		r    *http.Response
+		want time.Time
+	}{
+		{"expires header", makeResponse("Thu, 01 Oct 2026 12:05:00 GMT"), now.Add(5 * time.Minute)},
+		{"expires after fallback", makeResponse("Thu, 01 Oct 2026 14:00:00 GMT"), fallback},
+		{"invalid expires", makeResponse("invalid"), fallback},
+		{"no expires", makeResponse(""), fallback},
+		{"no response", nil, fallback},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, responseExpires(tc.r, fallback))
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Slots of a fitting in display order
+const (
+	slotHull = iota
+	slotHigh
+	slotMedium
+	slotLow
+	slotRig
+	slotSubsystem
+	slotDrone
+	slotCharge
+	slotCargo
+)
+
+var slotNames = map[int]string{
+	slotHull:      "Hull",
+	slotHigh:      "High",
+	slotMedium:    "Medium",
+	slotLow:       "Low",
+	slotRig:       "Rig",
+	slotSubsystem: "Subsystem",
+	slotDrone:     "Drone",
+	slotCharge:    "Charge",
+	slotCargo:     "Cargo",
+}
+
+// Dogma effects which define the slot of a module
+const (
+	effectLowPower  = 11
+	effectHighPower = 12
+	effectMedPower  = 13
+	effectRigSlot   = 2663
+	effectSubSystem = 3772
+)
+
+var effectSlots = map[int32]int{
+	effectHighPower: slotHigh,
+	effectMedPower:  slotMedium,
+	effectLowPower:  slotLow,
+	effectRigSlot:   slotRig,
+	effectSubSystem: slotSubsystem,
+}
+
+// Categories of inventory types which are not fitted into slots
+const (
+	categoryCharge = 8
+	categoryDrone  = 18
+)
+
+// fitting is a ship fitting parsed from the EFT format.
+type fitting struct {
+	ship  string
+	name  string
+	items []appraisalItem // fitted modules and their loaded charges
+	cargo []appraisalItem // items with a quantity from the drone bay and cargo sections
+}
+
+var (
+	rxFitHeader   = regexp.MustCompile(`^\[([^,\]]+),\s*(.*)\]$`)
+	rxFitQuantity = regexp.MustCompile(`^(.+?)\s+x(\d+)$`)
+)
+
+// parseEFT returns the fittings from lines in the EFT format.
+// Empty slots are skipped and modules with a loaded charge are returned as two items.
+// Lines with a quantity are from the drone bay and cargo sections, even when they are modules.
+func parseEFT(lines []string) ([]fitting, error) {
+	var fits []fitting
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := rxFitHeader.FindStringSubmatch(line); m != nil {
+			fits = append(fits, fitting{ship: strings.TrimSpace(m[1]), name: strings.TrimSpace(m[2])})
+			continue
+		}
+		if len(fits) == 0 {
+			return nil, fmt.Errorf("not a fitting in EFT format: %s", line)
+		}
+		if strings.HasPrefix(line, "[") {
+			continue // empty slot, e.g. [Empty High slot]
+		}
+		f := &fits[len(fits)-1]
+		if m := rxFitQuantity.FindStringSubmatch(line); m != nil {
+			n, _ := strconv.ParseInt(m[2], 10, 64)
+			f.cargo = append(f.cargo, appraisalItem{name: m[1], quantity: n})
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimSuffix(line, "/OFFLINE"))
+		module, charge, found := strings.Cut(line, ",")
+		f.items = append(f.items, appraisalItem{name: strings.TrimSpace(module), quantity: 1})
+		if found {
+			f.items = append(f.items, appraisalItem{name: strings.TrimSpace(charge), quantity: 1})
+		}
+	}
+	if len(fits) == 0 {
+		return nil, fmt.Errorf("the fit command needs a fitting in EFT format from stdin")
+	}
+	return fits, nil
+}
+
+// RunFit shows the hull and items of fittings in EFT format grouped by slot.
+func (a App) RunFit(lines []string) error {
+	fits, err := parseEFT(lines)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, f := range fits {
+		names = append(names, f.ship)
+		for _, x := range slices.Concat(f.items, f.cargo) {
+			names = append(names, x.name)
+		}
+	}
+	names = sliceUnique(names)
+	bar := a.newSpinner(fmt.Sprintf("Resolving %d items ...", len(names)))
+	entities, err := a.resolveValues(nil, names)
+	if err != nil {
+		return err
+	}
+	typeIDs := make(map[string]int32)
+	for _, e := range entities {
+		if e.Category == CategoryInventoryType {
+			typeIDs[e.Name] = e.ID()
+		}
+	}
+	var ids []int32
+	var unknown []string
+	for _, n := range names {
+		if id, ok := typeIDs[n]; ok {
+			ids = append(ids, id)
+		} else {
+			unknown = append(unknown, n)
+		}
+	}
+	types, err := a.fetchTypes(ids)
+	if err != nil {
+		return err
+	}
+	typeLookup := makeLookupMap(types)
+	var groupIDs []int32
+	for _, o := range types {
+		groupIDs = append(groupIDs, o.GroupID)
+	}
+	groups, err := a.fetchGroups(groupIDs)
+	if err != nil {
+		return err
+	}
+	groupLookup := makeLookupMap(groups)
+	var categoryIDs []int32
+	for _, o := range groups {
+		categoryIDs = append(categoryIDs, o.CategoryID)
+	}
+	categories, err := a.fetchCategories(categoryIDs)
+	if err != nil {
+		return err
+	}
+	categoryLookup := makeLookupMap(categories)
+	showPrices := a.Prices || a.Market != ""
+	var unitPrices map[int32]float64
+	if showPrices {
+		unitPrices, err = a.fetchUnitPrices(ids)
+		if err != nil {
+			return err
+		}
+	}
+	var results []result
+	for _, f := range fits {
+		type row struct {
+			slot     int
+			o        EveType
+			quantity int64
+		}
+		var rows []row
+		addRow := func(slot int, o EveType, quantity int64) {
+			i := slices.IndexFunc(rows, func(r row) bool {
+				return r.slot == slot && r.o.ID() == o.ID()
+			})
+			if i == -1 {
+				rows = append(rows, row{slot, o, quantity})
+				return
+			}
+			rows[i].quantity += quantity
+		}
+		if id, ok := typeIDs[f.ship]; ok {
+			addRow(slotHull, typeLookup[id], 1)
+		}
+		for _, x := range f.items {
+			id, ok := typeIDs[x.name]
+			if !ok {
+				continue
+			}
+			o := typeLookup[id]
+			addRow(typeSlot(o, groupLookup[o.GroupID].CategoryID), o, x.quantity)
+		}
+		for _, x := range f.cargo {
+			id, ok := typeIDs[x.name]
+			if !ok {
+				continue
+			}
+			o := typeLookup[id]
+			slot := slotCargo
+			if groupLookup[o.GroupID].CategoryID == categoryDrone {
+				slot = slotDrone
+			}
+			addRow(slot, o, x.quantity)
+		}
+		slices.SortStableFunc(rows, func(x, y row) int {
+			return cmp.Compare(x.slot, y.slot)
+		})
+		headers := []string{"Slot", "Quantity", "TypeID", "TypeName", "GroupName", "CategoryName", "Volume"}
+		if showPrices {
+			headers = append(headers, "UnitPrice", "Value")
+		}
+		t := &table{headers: headers}
+		var totalVolume, totalValue float64
+		for _, r := range rows {
+			group := groupLookup[r.o.GroupID]
+			volume := float64(r.quantity) * float64(cmp.Or(r.o.PackagedVolume, r.o.Volume))
+			totalVolume += volume
+			values := []any{
+				slotNames[r.slot],
+				r.quantity,
+				r.o.ID(),
+				r.o.Name,
+				group.Name,
+				categoryLookup[group.CategoryID].Name,
+				formatNumber(volume),
+			}
+			if showPrices {
+				value := float64(r.quantity) * unitPrices[r.o.ID()]
+				totalValue += value
+				values = append(values, formatISK(unitPrices[r.o.ID()]), formatISK(value))
+			}
+			t.rows = append(t.rows, tableRow{values: values})
+		}
+		title := fmt.Sprintf("%s - %s (%s m3", f.ship, f.name, formatNumber(totalVolume))
+		if showPrices {
+			title += fmt.Sprintf(", %s ISK", formatNumber(totalValue))
+		}
+		title += ")"
+		results = append(results, result{title, t})
+	}
+	if bar != nil {
+		bar.Clear()
+	}
+	for _, n := range unknown {
+		fmt.Fprintf(a.out, "Unknown type: %s\n", n)
+	}
+	return a.printResults(results)
+}
+
+// typeSlot returns the slot of a fitted inventory type.
+// Modules are identified by their dogma effects and all other items are cargo.
+func typeSlot(o EveType, categoryID int32) int {
+	for _, e := range o.DogmaEffects {
+		if slot, ok := effectSlots[e.EffectID]; ok {
+			return slot
+		}
+	}
+	switch categoryID {
+	case categoryDrone:
+		return slotDrone
+	case categoryCharge:
+		return slotCharge
+	}
+	return slotCargo
+}
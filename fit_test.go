@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var merlinFit = []string{
+	"[Merlin, Blaster Merlin]",
+	"Damage Control II",
+	"Magnetic Field Stabilizer II /OFFLINE",
+	"[Empty Low slot]",
+	"",
+	"1MN Afterburner II",
+	"",
+	"Light Neutron Blaster II, Caldari Navy Antimatter Charge S",
+	"Light Neutron Blaster II, Caldari Navy Antimatter Charge S",
+	"",
+	"Small Hybrid Burst Aerator I",
+	"",
+	"Hobgoblin I x2",
+	"",
+	"Caldari Navy Antimatter Charge S x200",
+	"Damage Control II x1",
+	"Unobtanium x1",
+}
+
+func TestParseEFT(t *testing.T) {
+	t.Run("can parse fitting", func(t *testing.T) {
+		fits, err := parseEFT(merlinFit)
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		if !assert.Len(t, fits, 1) {
+			t.Fatal()
+		}
+		f := fits[0]
+		assert.Equal(t, "Merlin", f.ship)
+		assert.Equal(t, "Blaster Merlin", f.name)
+		assert.Equal(t, []appraisalItem{
+			{"Damage Control II", 1},
+			{"Magnetic Field Stabilizer II", 1},
+			{"1MN Afterburner II", 1},
+			{"Light Neutron Blaster II", 1},
+			{"Caldari Navy Antimatter Charge S", 1},
+			{"Light Neutron Blaster II", 1},
+			{"Caldari Navy Antimatter Charge S", 1},
+			{"Small Hybrid Burst Aerator I", 1},
+		}, f.items)
+		assert.Equal(t, []appraisalItem{
+			{"Hobgoblin I", 2},
+			{"Caldari Navy Antimatter Charge S", 200},
+			{"Damage Control II", 1},
+			{"Unobtanium", 1},
+		}, f.cargo)
+	})
+	t.Run("can parse multiple fittings", func(t *testing.T) {
+		fits, err := parseEFT([]string{"[Merlin, A]", "Damage Control II", "[Kestrel, B]", "Damage Control II"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Len(t, fits, 2)
+	})
+	t.Run("should report error when not in EFT format", func(t *testing.T) {
+		_, err := parseEFT([]string{"Damage Control II"})
+		assert.Error(t, err)
+	})
+	t.Run("should report error when empty", func(t *testing.T) {
+		_, err := parseEFT(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestApp_RunFit(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/ids/`,
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"inventory_types": []map[string]any{
+				{"id": 603, "name": "Merlin"},
+				{"id": 2048, "name": "Damage Control II"},
+				{"id": 10190, "name": "Magnetic Field Stabilizer II"},
+				{"id": 438, "name": "1MN Afterburner II"},
+				{"id": 3178, "name": "Light Neutron Blaster II"},
+				{"id": 23009, "name": "Caldari Navy Antimatter Charge S"},
+				{"id": 31378, "name": "Small Hybrid Burst Aerator I"},
+				{"id": 2454, "name": "Hobgoblin I"},
+			},
+		}),
+	)
+	effect := func(id int) []map[string]any {
+		return []map[string]any{{"effect_id": 16}, {"effect_id": id}}
+	}
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/types/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			603:   {"name": "Merlin", "group_id": 25, "volume": 16500, "packaged_volume": 2500},
+			2048:  {"name": "Damage Control II", "group_id": 60, "volume": 5, "dogma_effects": effect(effectLowPower)},
+			10190: {"name": "Magnetic Field Stabilizer II", "group_id": 302, "volume": 5, "dogma_effects": effect(effectLowPower)},
+			438:   {"name": "1MN Afterburner II", "group_id": 46, "volume": 5, "dogma_effects": effect(effectMedPower)},
+			3178:  {"name": "Light Neutron Blaster II", "group_id": 74, "volume": 5, "dogma_effects": effect(effectHighPower)},
+			23009: {"name": "Caldari Navy Antimatter Charge S", "group_id": 85, "volume": 0.0025},
+			31378: {"name": "Small Hybrid Burst Aerator I", "group_id": 779, "volume": 5, "dogma_effects": effect(effectRigSlot)},
+			2454:  {"name": "Hobgoblin I", "group_id": 100, "volume": 5},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/groups/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			25:  {"name": "Frigate", "category_id": 6},
+			60:  {"name": "Damage Control", "category_id": 7},
+			302: {"name": "Magnetic Field Stabilizer", "category_id": 7},
+			46:  {"name": "Propulsion Module", "category_id": 7},
+			74:  {"name": "Hybrid Weapon", "category_id": 7},
+			85:  {"name": "Hybrid Charge", "category_id": categoryCharge},
+			779: {"name": "Rig Energy Weapon", "category_id": 7},
+			100: {"name": "Combat Drone", "category_id": categoryDrone},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/categories/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			6:  {"name": "Ship"},
+			7:  {"name": "Module"},
+			8:  {"name": "Charge"},
+			18: {"name": "Drone"},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/markets/prices/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"type_id": 603, "average_price": 300000},
+			{"type_id": 2048, "average_price": 500000},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show fitting grouped by slot", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunFit(merlinFit)
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Unknown type: Unobtanium")
+		assert.Contains(t, got, "Merlin - Blaster Merlin (2,545.50 m3):")
+		assert.Regexp(t, `(?s)`+
+			`Hull\s+│ 1\s+│ 603\s+│ Merlin\s+│ Frigate\s+│ Ship\s+│ 2,500.00.+`+
+			`High\s+│ 2\s+│ 3178\s+│ Light Neutron Blaster II\s+│ Hybrid Weapon\s+│ Module.+`+
+			`Medium\s+│ 1\s+│ 438.+`+
+			`Low\s+│ 1\s+│ 2048.+`+
+			`Low\s+│ 1\s+│ 10190.+`+
+			`Rig\s+│ 1\s+│ 31378.+`+
+			`Drone\s+│ 2\s+│ 2454\s+│ Hobgoblin I\s+│ Combat Drone\s+│ Drone\s+│ 10.00.+`+
+			`Charge\s+│ 2\s+│ 23009.+`+
+			`Cargo\s+│ 200\s+│ 23009.+`+
+			`Cargo\s+│ 1\s+│ 2048\s+│ Damage Control II`, got)
+		assert.NotContains(t, got, "UNIT PRICE")
+	})
+	t.Run("can show fitting with prices", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Prices = true
+		err := a.RunFit(merlinFit)
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Merlin - Blaster Merlin (2,545.50 m3, 1,300,000.00 ISK):")
+		assert.Regexp(t, `Hull\s+│ 1\s+│ 603\s+│ Merlin.+│ 300,000.00\s+│ 300,000.00`, got)
+	})
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/antihax/goesi/esi"
+)
+
+// fwStatusUncontested is the status of faction warfare systems which are currently not contested.
+const fwStatusUncontested = "uncontested"
+
+// RunFW shows the current faction warfare statistics per faction and the contested systems.
+func (a App) RunFW(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("the fw command does not take any values")
+	}
+	standings, err := a.resolveWatchlist()
+	if err != nil {
+		return err
+	}
+	a.standings = standings
+	bar := a.newSpinner("Fetching faction warfare statistics ...")
+	stats, err := a.buildFWStatsTable()
+	if err != nil {
+		return err
+	}
+	systems, err := a.buildFWSystemsTable()
+	if err != nil {
+		return err
+	}
+	if bar != nil {
+		bar.Clear()
+	}
+	return a.printResults([]result{
+		{"Faction warfare", stats},
+		{fmt.Sprintf("Contested systems (%d)", len(systems.rows)), systems},
+	})
+}
+
+// buildFWStatsTable returns a table with kills and victory points for each faction in faction warfare.
+func (a App) buildFWStatsTable() (*table, error) {
+	data, _, err := a.esiClient.ESI.FactionWarfareApi.GetFwStats(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var factionIDs []int32
+	for _, x := range data {
+		factionIDs = append(factionIDs, x.FactionId)
+	}
+	factions, err := a.fetchFactions(factionIDs)
+	if err != nil {
+		return nil, err
+	}
+	factionLookup := makeLookupMap(factions)
+	slices.SortFunc(data, func(x, y esi.GetFwStats200Ok) int {
+		return cmp.Compare(x.FactionId, y.FactionId)
+	})
+	t := &table{headers: []string{
+		"FactionID",
+		"FactionName",
+		"Pilots",
+		"SystemsControlled",
+		"KillsYesterday",
+		"KillsLastWeek",
+		"KillsTotal",
+		"VPYesterday",
+		"VPLastWeek",
+		"VPTotal",
+	}}
+	for _, x := range data {
+		t.rows = append(t.rows, tableRow{
+			values: []any{
+				x.FactionId,
+				factionLookup[x.FactionId].Name,
+				x.Pilots,
+				x.SystemsControlled,
+				x.Kills.Yesterday,
+				x.Kills.LastWeek,
+				x.Kills.Total,
+				x.VictoryPoints.Yesterday,
+				x.VictoryPoints.LastWeek,
+				x.VictoryPoints.Total,
+			},
+			color: a.highlightColor(x.FactionId),
+		})
+	}
+	return t, nil
+}
+
+// buildFWSystemsTable returns a table with all contested faction warfare systems
+// sorted by their progress in descending order.
+func (a App) buildFWSystemsTable() (*table, error) {
+	data, _, err := a.esiClient.ESI.FactionWarfareApi.GetFwSystems(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	data = slices.DeleteFunc(data, func(x esi.GetFwSystems200Ok) bool {
+		return x.Contested == fwStatusUncontested
+	})
+	var systemIDs, factionIDs []int32
+	for _, x := range data {
+		systemIDs = append(systemIDs, x.SolarSystemId)
+		factionIDs = append(factionIDs, x.OwnerFactionId, x.OccupierFactionId)
+	}
+	locations, err := a.fetchLocations(systemIDs)
+	if err != nil {
+		return nil, err
+	}
+	factions, err := a.fetchFactions(factionIDs)
+	if err != nil {
+		return nil, err
+	}
+	factionLookup := makeLookupMap(factions)
+	progress := func(x esi.GetFwSystems200Ok) float32 {
+		if x.VictoryPointsThreshold == 0 {
+			return 0
+		}
+		return float32(x.VictoryPoints) / float32(x.VictoryPointsThreshold)
+	}
+	slices.SortFunc(data, func(x, y esi.GetFwSystems200Ok) int {
+		return cmp.Or(cmp.Compare(progress(y), progress(x)), cmp.Compare(x.SolarSystemId, y.SolarSystemId))
+	})
+	t := &table{headers: []string{
+		"SolarSystemID",
+		"SolarSystemName",
+		"RegionName",
+		"OwnerFactionName",
+		"OccupierFactionName",
+		"Status",
+		"VictoryPoints",
+		"Threshold",
+		"Progress",
+	}}
+	for _, x := range data {
+		l := locations[x.SolarSystemId]
+		t.rows = append(t.rows, tableRow{
+			values: []any{
+				x.SolarSystemId,
+				l.solarSystem.Name,
+				l.region.Name,
+				factionLookup[x.OwnerFactionId].Name,
+				factionLookup[x.OccupierFactionId].Name,
+				x.Contested,
+				x.VictoryPoints,
+				x.VictoryPointsThreshold,
+				formatPercent(progress(x)),
+			},
+			color: a.highlightColor(x.OccupierFactionId),
+		})
+	}
+	return t, nil
+}
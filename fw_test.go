@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_RunFW(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/fw/stats/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{
+				"faction_id":         500002,
+				"kills":              map[string]any{"last_week": 700, "total": 90000, "yesterday": 100},
+				"pilots":             3000,
+				"systems_controlled": 60,
+				"victory_points":     map[string]any{"last_week": 70000, "total": 9000000, "yesterday": 10000},
+			},
+			{
+				"faction_id":         500001,
+				"kills":              map[string]any{"last_week": 800, "total": 95000, "yesterday": 120},
+				"pilots":             3500,
+				"systems_controlled": 40,
+				"victory_points":     map[string]any{"last_week": 80000, "total": 9500000, "yesterday": 12000},
+			},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/fw/systems/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"contested": "contested", "occupier_faction_id": 500001, "owner_faction_id": 500001, "solar_system_id": 30002537, "victory_points": 1500, "victory_points_threshold": 3000},
+			{"contested": "vulnerable", "occupier_faction_id": 500002, "owner_faction_id": 500002, "solar_system_id": 30002538, "victory_points": 3000, "victory_points_threshold": 3000},
+			{"contested": "uncontested", "occupier_faction_id": 500001, "owner_faction_id": 500001, "solar_system_id": 30002539, "victory_points": 0, "victory_points_threshold": 3000},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/factions/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"faction_id": 500001, "name": "Caldari State", "description": "dummy"},
+			{"faction_id": 500002, "name": "Minmatar Republic", "description": "dummy"},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/systems/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			30002537: {"name": "Amamake", "constellation_id": 20000372, "security_status": 0.4},
+			30002538: {"name": "Vard", "constellation_id": 20000372, "security_status": 0.4},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/constellations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			20000372: {"name": "Hed", "region_id": 10000042},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/regions/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			10000042: {"name": "Metropolis"},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show faction warfare statistics", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunFW(nil)
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Faction warfare:")
+		assert.Regexp(t, `500001\s+│ Caldari State\s+│ 3500\s+│ 40\s+│ 120\s+│ 800\s+│ 95000`, got)
+		assert.Regexp(t, `500002\s+│ Minmatar Republic\s+│ 3000`, got)
+	})
+	t.Run("can show contested systems sorted by progress", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunFW(nil)
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Contested systems (2):")
+		assert.Regexp(t, `(?s)Vard\s+│ Metropolis\s+│ Minmatar Republic.+│ vulnerable.+100\.0%.+Amamake.+│ contested.+50\.0%`, got)
+		assert.NotContains(t, got, "30002539")
+	})
+	t.Run("should report error when values are given", func(t *testing.T) {
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunFW([]string{"Amamake"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// maxPathJumps is the maximum number of jumps searched for the shortest path between two solar systems.
+const maxPathJumps = 100
+
+// RunJumps shows the shortest path between two solar systems through stargates.
+// The path is calculated locally from the cached stargate graph.
+func (a App) RunJumps(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("the jumps command needs an origin and a destination")
+	}
+	bar := a.newSpinner("Calculating jumps ...")
+	origin, err := a.resolveSolarSystem(args[0])
+	if err != nil {
+		return err
+	}
+	destination, err := a.resolveSolarSystem(args[1])
+	if err != nil {
+		return err
+	}
+	path, err := a.shortestPath(origin.ID(), destination.ID(), maxPathJumps)
+	if err != nil {
+		return err
+	}
+	systems, err := a.fetchSolarSystems([]int32{origin.ID(), destination.ID()})
+	if err != nil {
+		return err
+	}
+	systemLookup := makeLookupMap(systems)
+	distance := systemLookup[origin.ID()].DistanceLY(systemLookup[destination.ID()])
+	if path == nil {
+		if bar != nil {
+			bar.Clear()
+		}
+		fmt.Fprintf(a.out, "No connection from %s to %s within %d jumps (%.2f ly direct distance)\n", origin.Name, destination.Name, maxPathJumps, distance)
+		return nil
+	}
+	t, _, err := a.buildRouteTable(path)
+	if err != nil {
+		return err
+	}
+	if bar != nil {
+		bar.Clear()
+	}
+	title := fmt.Sprintf("Shortest path from %s to %s (%d jumps, %.2f ly direct distance)", origin.Name, destination.Name, len(path)-1, distance)
+	return a.printResults([]result{{title, t}})
+}
+
+// RunRange shows all solar systems within a number of jumps from a solar system
+// with their direct distance in light years.
+func (a App) RunRange(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("the range command needs exactly one solar system")
+	}
+	if a.RangeJumps < 1 {
+		return fmt.Errorf("the number of jumps must be at least 1")
+	}
+	bar := a.newSpinner("Calculating range ...")
+	origin, err := a.resolveSolarSystem(args[0])
+	if err != nil {
+		return err
+	}
+	g, err := a.loadStargateGraph(origin.ID())
+	if err != nil {
+		return err
+	}
+	jumps, _ := g.walk(origin.ID(), a.RangeJumps, 0)
+	ids := make([]int32, 0, len(jumps))
+	for id := range jumps {
+		ids = append(ids, id)
+	}
+	locations, err := a.fetchLocations(ids)
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(ids, func(x, y int32) int {
+		return cmp.Or(cmp.Compare(jumps[x], jumps[y]), cmp.Compare(locations[x].solarSystem.Name, locations[y].solarSystem.Name))
+	})
+	originSystem := locations[origin.ID()].solarSystem
+	t := &table{headers: []string{"Jumps", "SolarSystemID", "SolarSystemName", "Security", "Band", "RegionName", "DistanceLY"}}
+	for _, id := range ids {
+		l := locations[id]
+		band := l.solarSystem.SecurityBand()
+		t.rows = append(t.rows, tableRow{
+			values: []any{
+				jumps[id],
+				id,
+				l.solarSystem.Name,
+				formatSecurity(l.solarSystem.DisplaySecurity()),
+				string(band),
+				l.region.Name,
+				fmt.Sprintf("%.2f", originSystem.DistanceLY(l.solarSystem)),
+			},
+			color: securityBandColors[band],
+		})
+	}
+	if bar != nil {
+		bar.Clear()
+	}
+	title := fmt.Sprintf("Solar systems within %d jumps of %s (%d systems)", a.RangeJumps, origin.Name, len(ids)-1)
+	return a.printResults([]result{{title, t}})
+}
+
+// stargateGraph maps solar systems to their neighbors through stargates.
+type stargateGraph map[int32][]int32
+
+// shortestPath returns the solar systems on the shortest path between two solar systems incl. both.
+// Returns nil when there is no path within the maximum number of jumps.
+func (a App) shortestPath(origin, destination int32, maxJumps int) ([]int32, error) {
+	g, err := a.loadStargateGraph(origin, destination)
+	if err != nil {
+		return nil, err
+	}
+	_, previous := g.walk(origin, maxJumps, destination)
+	if _, ok := previous[destination]; !ok && origin != destination {
+		return nil, nil
+	}
+	path := []int32{destination}
+	for id := destination; id != origin; {
+		id = previous[id]
+		path = append(path, id)
+	}
+	slices.Reverse(path)
+	return path, nil
+}
+
+// walk walks the graph breadth first from the origin up to a maximum number of jumps.
+// It stops early when the target is reached, unless the target is 0.
+// It returns the number of jumps to each reached solar system
+// and the previous solar system on the shortest path to it.
+func (g stargateGraph) walk(origin int32, maxJumps int, target int32) (map[int32]int, map[int32]int32) {
+	jumps := map[int32]int{origin: 0}
+	previous := make(map[int32]int32)
+	frontier := []int32{origin}
+	for n := 1; n <= maxJumps && len(frontier) > 0; n++ {
+		slices.Sort(frontier)
+		next := make([]int32, 0)
+		for _, id := range frontier {
+			for _, neighbor := range g[id] {
+				if _, ok := jumps[neighbor]; ok {
+					continue
+				}
+				jumps[neighbor] = n
+				previous[neighbor] = id
+				next = append(next, neighbor)
+			}
+		}
+		frontier = next
+		if _, ok := jumps[target]; ok && target != 0 {
+			break
+		}
+	}
+	return jumps, previous
+}
+
+// loadStargateGraph returns the stargate graph of New Eden from the cache.
+// The graph is complete when it contains the required solar systems
+// and the neighbors of every cached solar system are cached too.
+// Otherwise all solar systems missing from the graph are downloaded once and stored,
+// which includes solar systems cached by older versions without their stargates.
+func (a App) loadStargateGraph(required ...int32) (stargateGraph, error) {
+	systems, err := a.st.ListEveSolarSystem()
+	if err != nil {
+		return nil, err
+	}
+	g := make(stargateGraph)
+	g.add(systems)
+	if g.isComplete(required) {
+		return g, nil
+	}
+	ids, _, err := a.esiClient.ESI.UniverseApi.GetUniverseSystems(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	missing := slices.DeleteFunc(ids, func(id int32) bool {
+		_, ok := g[id]
+		return ok
+	})
+	slog.Info("Downloading stargate graph", "missing", len(missing))
+	systems, err = a.fetchSolarSystemsWithNeighbors(missing)
+	if err != nil {
+		return nil, err
+	}
+	g.add(systems)
+	return g, nil
+}
+
+// add adds solar systems with known neighbors to the graph.
+func (g stargateGraph) add(systems []EveSolarSystem) {
+	for _, o := range systems {
+		if o.HasNeighbors() {
+			g[o.ID()] = o.NeighborIDs
+		}
+	}
+}
+
+// isComplete reports whether the graph contains the required solar systems and all neighbors.
+func (g stargateGraph) isComplete(required []int32) bool {
+	for _, id := range required {
+		if _, ok := g[id]; !ok {
+			return false
+		}
+	}
+	for _, neighbors := range g {
+		for _, id := range neighbors {
+			if _, ok := g[id]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// fetchSolarSystemsWithNeighbors returns solar systems with their neighbors.
+// Missing neighbors are resolved from the stargates of a solar system and stored.
+func (a App) fetchSolarSystemsWithNeighbors(ids []int32) ([]EveSolarSystem, error) {
+	systems, err := a.fetchSolarSystems(ids)
+	if err != nil {
+		return nil, err
+	}
+	var stargateIDs []int32
+	for _, o := range systems {
+		if !o.HasNeighbors() {
+			stargateIDs = append(stargateIDs, o.StargateIDs...)
+		}
+	}
+	if len(stargateIDs) == 0 {
+		return systems, nil
+	}
+	stargates, err := a.fetchStargates(stargateIDs)
+	if err != nil {
+		return nil, err
+	}
+	stargateLookup := makeLookupMap(stargates)
+	var updated []EveSolarSystem
+	for i, o := range systems {
+		if o.HasNeighbors() {
+			continue
+		}
+		neighbors := make([]int32, 0, len(o.StargateIDs))
+		for _, id := range o.StargateIDs {
+			if x, ok := stargateLookup[id]; ok && x.DestinationSolarSystemID != 0 {
+				neighbors = append(neighbors, x.DestinationSolarSystemID)
+			}
+		}
+		o.NeighborIDs = slices.Sorted(slices.Values(sliceUnique(neighbors)))
+		systems[i] = o
+		updated = append(updated, o)
+	}
+	if err := a.st.UpdateOrCreateEveSolarSystem(updated); err != nil {
+		return nil, err
+	}
+	return systems, nil
+}
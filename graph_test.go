@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// The fixture universe consists of these solar systems connected by stargates:
+//
+//	Alpha - Bravo - Charlie - Delta
+//	          |
+//	        Echo
+//
+//	Foxtrot (not connected)
+func TestApp_RunJumpsAndRange(t *testing.T) {
+	entities := []entity{
+		{30000001, "Alpha", "solar_system"},
+		{30000002, "Bravo", "solar_system"},
+		{30000003, "Charlie", "solar_system"},
+		{30000004, "Delta", "solar_system"},
+		{30000005, "Echo", "solar_system"},
+		{30000006, "Foxtrot", "solar_system"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint(entities),
+	)
+	makeSystem := func(name string, security float64, x float64, stargates ...int) map[string]any {
+		return map[string]any{
+			"constellation_id": 20000001,
+			"name":             name,
+			"position":         map[string]any{"x": x, "y": lightYear, "z": 0}, // no system is at the origin
+			"security_status":  security,
+			"stargates":        stargates,
+		}
+	}
+	systemsURL := `=~^https://esi\.evetech\.net/v\d+/universe/systems/$`
+	httpmock.RegisterResponder(
+		"GET",
+		systemsURL,
+		httpmock.NewJsonResponderOrPanic(200, []int{30000001, 30000002, 30000003, 30000004, 30000005, 30000006}),
+	)
+	systemURL := `=~^https://esi\.evetech\.net/v\d+/universe/systems/(\d+)/`
+	httpmock.RegisterResponder(
+		"GET",
+		systemURL,
+		makeObjectResponder(map[int64]map[string]any{
+			30000001: makeSystem("Alpha", 1.0, 0, 50000001),
+			30000002: makeSystem("Bravo", 0.5, lightYear, 50000002, 50000003, 50000007),
+			30000003: makeSystem("Charlie", 0.3, 1.5*lightYear, 50000004, 50000005),
+			30000004: makeSystem("Delta", -0.5, 2*lightYear, 50000006),
+			30000005: makeSystem("Echo", 0.7, 3*lightYear, 50000008),
+			30000006: makeSystem("Foxtrot", 0.9, 10*lightYear),
+		}),
+	)
+	makeStargate := func(from, to int) map[string]any {
+		return map[string]any{"destination": map[string]any{"system_id": to}, "name": "Stargate", "system_id": from}
+	}
+	stargatesURL := `=~^https://esi\.evetech\.net/v\d+/universe/stargates/(\d+)/`
+	httpmock.RegisterResponder(
+		"GET",
+		stargatesURL,
+		makeObjectResponder(map[int64]map[string]any{
+			50000001: makeStargate(30000001, 30000002),
+			50000002: makeStargate(30000002, 30000001),
+			50000003: makeStargate(30000002, 30000003),
+			50000004: makeStargate(30000003, 30000002),
+			50000005: makeStargate(30000003, 30000004),
+			50000006: makeStargate(30000004, 30000003),
+			50000007: makeStargate(30000002, 30000005),
+			50000008: makeStargate(30000005, 30000002),
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/constellations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			20000001: {"name": "Constellation", "region_id": 10000001},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/regions/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			10000001: {"name": "Region"},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show shortest path with distance", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunJumps([]string{"30000001", "30000004"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Shortest path from Alpha to Delta (3 jumps, 2.00 ly direct distance):")
+		assert.Regexp(t, `(?s)Alpha.+Bravo.+Charlie.+Delta`, got)
+		assert.NotContains(t, got, "Echo")
+	})
+	t.Run("can calculate jumps from cached graph only", func(t *testing.T) {
+		st.MustClear()
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		err := a.RunJumps([]string{"30000005", "30000004"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		httpmock.ZeroCallCounters()
+		var buf bytes.Buffer
+		a = NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err = a.RunJumps([]string{"30000001", "30000006"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Contains(t, buf.String(), "No connection from Alpha to Foxtrot")
+		info := httpmock.GetCallCountInfo()
+		assert.Equal(t, 0, info["GET "+systemsURL])
+		assert.Equal(t, 0, info["GET "+systemURL])
+		assert.Equal(t, 0, info["GET "+stargatesURL])
+	})
+	t.Run("should refetch solar systems cached without stargates", func(t *testing.T) {
+		st.MustClear()
+		err := st.UpdateOrCreateEveSolarSystem([]EveSolarSystem{{
+			ConstellationID: 20000001,
+			Name:            "Bravo",
+			SolarSystemID:   30000002,
+			Timestamp:       time.Now().UTC(),
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err = a.RunJumps([]string{"30000001", "30000004"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Contains(t, buf.String(), "Shortest path from Alpha to Delta (3 jumps, 2.00 ly direct distance):")
+	})
+	t.Run("should report when solar systems are not connected", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunJumps([]string{"30000001", "30000006"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Contains(t, buf.String(), "No connection from Alpha to Foxtrot within 100 jumps (10.00 ly direct distance)")
+	})
+	t.Run("can show solar systems in range", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.RangeJumps = 2
+		err := a.RunRange([]string{"30000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Solar systems within 2 jumps of Alpha (3 systems):")
+		assert.Regexp(t, `(?s)Alpha.+Bravo.+Charlie.+Echo`, got)
+		assert.Contains(t, got, colorize("Charlie", colorYellow))
+		assert.Contains(t, got, colorize("1.50", colorYellow))
+		assert.Contains(t, got, colorize("3.00", colorGreen))
+		assert.NotContains(t, got, "Delta")
+	})
+}
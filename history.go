@@ -0,0 +1,169 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// historyRecord represents a membership in an organization, e.g. of a character in a corporation.
+type historyRecord struct {
+	id        int32 // ID of the organization
+	isDeleted bool
+	recordID  int32
+	startDate time.Time
+	endDate   time.Time // zero for the current membership
+}
+
+// Tenure returns how long the membership lasted or has lasted so far.
+func (r historyRecord) Tenure() age {
+	end := r.endDate
+	if end.IsZero() {
+		end = now()
+	}
+	return age(end.Sub(r.startDate))
+}
+
+// completeHistory sorts history records from newest to oldest
+// and sets the end date of each record to the start date of its successor.
+func completeHistory(records []historyRecord) []historyRecord {
+	slices.SortFunc(records, func(a, b historyRecord) int {
+		return cmp.Compare(b.recordID, a.recordID)
+	})
+	for i := 1; i < len(records); i++ {
+		records[i].endDate = records[i-1].startDate
+	}
+	return records
+}
+
+// RunHistory shows the corporation history for characters and the alliance history for corporations.
+func (a App) RunHistory(args []string) error {
+	ids, names, err := a.parseValues(args)
+	if err != nil {
+		return err
+	}
+	standings, err := a.resolveWatchlist()
+	if err != nil {
+		return err
+	}
+	a.standings = standings
+	bar := a.newSpinner(fmt.Sprintf("Fetching history for %d IDs/names ...", len(ids)+len(names)))
+	entities, err := a.resolveValues(ids, names)
+	if err != nil {
+		return err
+	}
+	var results []result
+	var unsupported []EveEntity
+	for _, e := range entities {
+		switch e.Category {
+		case CategoryCharacter:
+			t, err := a.buildCorporationHistoryTable(e.ID())
+			if err != nil {
+				return err
+			}
+			results = append(results, result{"Corporation history of " + e.Name, t})
+		case CategoryCorporation:
+			t, err := a.buildAllianceHistoryTable(e.ID())
+			if err != nil {
+				return err
+			}
+			results = append(results, result{"Alliance history of " + e.Name, t})
+		default:
+			unsupported = append(unsupported, e)
+		}
+	}
+	if bar != nil {
+		bar.Clear()
+	}
+	for _, e := range unsupported {
+		fmt.Fprintf(a.out, "No history available for %s: %s\n", e.Category.Display(), entityDisplayName(e))
+	}
+	return a.printResults(results)
+}
+
+func (a App) buildCorporationHistoryTable(characterID int32) (*table, error) {
+	data, _, err := a.esiClient.ESI.CharacterApi.GetCharactersCharacterIdCorporationhistory(context.Background(), characterID, nil)
+	if err != nil {
+		return nil, err
+	}
+	var records []historyRecord
+	var corporationIDs []int32
+	for _, x := range data {
+		records = append(records, historyRecord{
+			id:        x.CorporationId,
+			isDeleted: x.IsDeleted,
+			recordID:  x.RecordId,
+			startDate: x.StartDate,
+		})
+		corporationIDs = append(corporationIDs, x.CorporationId)
+	}
+	records = completeHistory(records)
+	corporations, err := a.fetchCorporations(corporationIDs)
+	if err != nil {
+		return nil, err
+	}
+	corporationLookup := makeLookupMap(corporations)
+	var allianceIDs []int32
+	for _, o := range corporations {
+		if o.AllianceID != 0 {
+			allianceIDs = append(allianceIDs, o.AllianceID)
+		}
+	}
+	entities, err := a.resolveIDs(allianceIDs)
+	if err != nil {
+		return nil, err
+	}
+	entityLookup := makeLookupMap(entities)
+	t := &table{headers: []string{"StartDate", "EndDate", "Tenure", "CorporationID", "CorporationName", "Ticker", "CurrentAllianceName", "Deleted"}}
+	for _, r := range records {
+		c := corporationLookup[r.id]
+		t.rows = append(t.rows, tableRow{
+			values: []any{formatDate(r.startDate), formatDate(r.endDate), r.Tenure(), r.id, c.Name, c.Ticker, entityLookup[c.AllianceID].Name, r.isDeleted},
+			color:  a.highlightColor(r.id, c.AllianceID),
+		})
+	}
+	return t, nil
+}
+
+func (a App) buildAllianceHistoryTable(corporationID int32) (*table, error) {
+	data, _, err := a.esiClient.ESI.CorporationApi.GetCorporationsCorporationIdAlliancehistory(context.Background(), corporationID, nil)
+	if err != nil {
+		return nil, err
+	}
+	var records []historyRecord
+	var allianceIDs []int32
+	for _, x := range data {
+		records = append(records, historyRecord{
+			id:        x.AllianceId,
+			isDeleted: x.IsDeleted,
+			recordID:  x.RecordId,
+			startDate: x.StartDate,
+		})
+		if x.AllianceId != 0 {
+			allianceIDs = append(allianceIDs, x.AllianceId)
+		}
+	}
+	records = completeHistory(records)
+	alliances, err := a.fetchAlliance(allianceIDs)
+	if err != nil {
+		return nil, err
+	}
+	allianceLookup := makeLookupMap(alliances)
+	t := &table{headers: []string{"StartDate", "EndDate", "Tenure", "AllianceID", "AllianceName", "Ticker", "Deleted"}}
+	for _, r := range records {
+		var name, ticker string
+		if r.id == 0 {
+			name = nameNoAlliance
+		} else {
+			o := allianceLookup[r.id]
+			name, ticker = o.Name, o.Ticker
+		}
+		t.rows = append(t.rows, tableRow{
+			values: []any{formatDate(r.startDate), formatDate(r.endDate), r.Tenure(), idOrEmpty(r.id), name, ticker, r.isDeleted},
+			color:  a.highlightColor(r.id),
+		})
+	}
+	return t, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompleteHistory(t *testing.T) {
+	t1 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	t3 := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := completeHistory([]historyRecord{
+		{id: 1, recordID: 1, startDate: t1},
+		{id: 3, recordID: 3, startDate: t3},
+		{id: 2, recordID: 2, startDate: t2},
+	})
+	want := []historyRecord{
+		{id: 3, recordID: 3, startDate: t3},
+		{id: 2, recordID: 2, startDate: t2, endDate: t3},
+		{id: 1, recordID: 1, startDate: t1, endDate: t2},
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, age(t3.Sub(t2)), got[1].Tenure())
+}
+
+func TestApp_RunHistory(t *testing.T) {
+	entities := []entity{
+		{1001, "Alpha", "character"},
+		{98000001, "Ropers Inc", "corporation"},
+		{1000009, "School of Applied Knowledge", "corporation"},
+		{99000001, "RAPID HEAVY ROPERS", "alliance"},
+		{99000002, "Closed Alliance", "alliance"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint(entities),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/characters/1001/corporationhistory/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"corporation_id": 1000009, "record_id": 10, "start_date": "2015-03-01T10:00:00Z"},
+			{"corporation_id": 98000001, "record_id": 11, "start_date": "2016-07-15T10:00:00Z"},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/corporations/98000001/alliancehistory/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"alliance_id": 99000002, "is_deleted": true, "record_id": 20, "start_date": "2017-01-10T10:00:00Z"},
+			{"record_id": 21, "start_date": "2018-02-01T10:00:00Z"},
+			{"alliance_id": 99000001, "record_id": 22, "start_date": "2019-05-20T10:00:00Z"},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/corporations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			1000009:  {"name": "School of Applied Knowledge", "ticker": "SAK", "member_count": 1000},
+			98000001: {"name": "Ropers Inc", "ticker": "ROPE1", "member_count": 20, "alliance_id": 99000001},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/alliances/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			99000001: {"name": "RAPID HEAVY ROPERS", "ticker": "ROPE"},
+			99000002: {"name": "Closed Alliance", "ticker": "GONE"},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show corporation history of a character", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunHistory([]string{"1001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Corporation history of Alpha:")
+		assert.Regexp(t, `2016-07-15\s+│\s+│ \S+.*│ 98000001\s+│ Ropers Inc\s+│ ROPE1\s+│ RAPID HEAVY ROPERS`, got)
+		assert.Regexp(t, `2015-03-01\s+│ 2016-07-15 │ 1y 4m\s+│ 1000009\s+│ School of Applied Knowledge`, got)
+	})
+	t.Run("can show alliance history of a corporation", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunHistory([]string{"98000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Alliance history of Ropers Inc:")
+		assert.Regexp(t, `2019-05-20\s+│\s+│ \S+.*│ 99000001\s+│ RAPID HEAVY ROPERS\s+│ ROPE\s+│ false`, got)
+		assert.Regexp(t, `2018-02-01\s+│ 2019-05-20 │ 1y 3m\s+│\s+│ \(no alliance\)`, got)
+		assert.Regexp(t, `2017-01-10\s+│ 2018-02-01 │ \S+.*│ 99000002\s+│ Closed Alliance\s+│ GONE\s+│ true`, got)
+	})
+	t.Run("should report entities without history", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunHistory([]string{"99000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "No history available for Alliance: RAPID HEAVY ROPERS")
+	})
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/antihax/goesi/esi"
+)
+
+var (
+	rxKillmailESI = regexp.MustCompile(`/killmails/(\d+)/([0-9a-fA-F]+)/?$`)
+	rxKillmailZKB = regexp.MustCompile(`zkillboard\.com/kill/(\d+)/?$`)
+)
+
+// parseKillmailArgs returns the ID and hash of a killmail
+// from an ID and hash, an ESI killmail URL or a zKillboard URL followed by the hash.
+func parseKillmailArgs(args []string) (int32, string, error) {
+	var id, hash string
+	switch {
+	case len(args) == 1 && rxKillmailESI.MatchString(args[0]):
+		m := rxKillmailESI.FindStringSubmatch(args[0])
+		id, hash = m[1], m[2]
+	case len(args) >= 1 && rxKillmailZKB.MatchString(args[0]):
+		if len(args) != 2 {
+			return 0, "", fmt.Errorf("zKillboard URLs do not contain the killmail hash. Please also provide the hash")
+		}
+		id, hash = rxKillmailZKB.FindStringSubmatch(args[0])[1], args[1]
+	case len(args) == 2:
+		id, hash = args[0], args[1]
+	default:
+		return 0, "", fmt.Errorf("the killmail command needs a killmail ID and hash or an ESI killmail URL")
+	}
+	x, err := strconv.ParseInt(id, 10, 32)
+	if err != nil || x < 1 {
+		return 0, "", fmt.Errorf("not a valid killmail ID: %s", id)
+	}
+	return int32(x), hash, nil
+}
+
+// killmailItemSlot returns the slot of an item on a killmail by its inventory flag.
+// Items in holds other than the known slots are reported as cargo.
+func killmailItemSlot(flag int32) int {
+	switch {
+	case flag >= 11 && flag <= 18:
+		return slotLow
+	case flag >= 19 && flag <= 26:
+		return slotMedium
+	case flag >= 27 && flag <= 34:
+		return slotHigh
+	case flag == 87:
+		return slotDrone
+	case flag >= 92 && flag <= 99:
+		return slotRig
+	case flag >= 125 && flag <= 132:
+		return slotSubsystem
+	}
+	return slotCargo
+}
+
+// RunKillmail shows the victim, attackers and items of a killmail.
+func (a App) RunKillmail(args []string) error {
+	id, hash, err := parseKillmailArgs(args)
+	if err != nil {
+		return err
+	}
+	standings, err := a.resolveWatchlist()
+	if err != nil {
+		return err
+	}
+	a.standings = standings
+	bar := a.newSpinner(fmt.Sprintf("Fetching killmail %d ...", id))
+	km, _, err := a.esiClient.ESI.KillmailsApi.GetKillmailsKillmailIdKillmailHash(context.Background(), hash, id, nil)
+	if err != nil {
+		return err
+	}
+	type item struct {
+		slot      int
+		typeID    int32
+		destroyed int64
+		dropped   int64
+	}
+	var items []item
+	for _, x := range km.Victim.Items {
+		slot := killmailItemSlot(x.Flag)
+		items = append(items, item{slot, x.ItemTypeId, x.QuantityDestroyed, x.QuantityDropped})
+		for _, y := range x.Items {
+			items = append(items, item{slot, y.ItemTypeId, y.QuantityDestroyed, y.QuantityDropped})
+		}
+	}
+	v := km.Victim
+	ids := []int32{v.CharacterId, v.CorporationId, v.AllianceId, v.FactionId, v.ShipTypeId, km.SolarSystemId}
+	for _, x := range km.Attackers {
+		ids = append(ids, x.CharacterId, x.CorporationId, x.AllianceId, x.FactionId, x.ShipTypeId, x.WeaponTypeId)
+	}
+	for _, x := range items {
+		ids = append(ids, x.typeID)
+	}
+	ids = slices.DeleteFunc(sliceUnique(ids), func(id int32) bool {
+		return id == 0
+	})
+	entities, err := a.resolveIDs(ids)
+	if err != nil {
+		return err
+	}
+	names := make(map[int32]string)
+	for _, e := range entities {
+		names[e.ID()] = e.Name
+	}
+	locations, err := a.fetchLocations([]int32{km.SolarSystemId})
+	if err != nil {
+		return err
+	}
+	loc := locations[km.SolarSystemId]
+
+	victim := &table{headers: []string{
+		"ID", "Time", "Victim", "Corporation", "Alliance", "Faction", "Ship",
+		"SolarSystem", "Security", "Region", "DamageTaken", "Attackers",
+	}}
+	victim.rows = append(victim.rows, tableRow{
+		values: []any{
+			km.KillmailId,
+			km.KillmailTime.UTC().Format("2006-01-02 15:04:05"),
+			names[v.CharacterId],
+			names[v.CorporationId],
+			names[v.AllianceId],
+			names[v.FactionId],
+			names[v.ShipTypeId],
+			names[km.SolarSystemId],
+			formatSecurity(loc.solarSystem.DisplaySecurity()),
+			loc.region.Name,
+			v.DamageTaken,
+			len(km.Attackers),
+		},
+		color: a.highlightColor(v.CharacterId, v.CorporationId, v.AllianceId),
+	})
+
+	attackers := slices.Clone(km.Attackers)
+	slices.SortStableFunc(attackers, func(x, y esi.GetKillmailsKillmailIdKillmailHashAttacker) int {
+		return cmp.Compare(y.DamageDone, x.DamageDone)
+	})
+	attackerTable := &table{headers: []string{
+		"Character", "Corporation", "Alliance", "Faction", "Ship", "Weapon", "Security", "Damage", "FinalBlow",
+	}}
+	for _, x := range attackers {
+		attackerTable.rows = append(attackerTable.rows, tableRow{
+			values: []any{
+				names[x.CharacterId],
+				names[x.CorporationId],
+				names[x.AllianceId],
+				names[x.FactionId],
+				names[x.ShipTypeId],
+				names[x.WeaponTypeId],
+				formatSecurity(x.SecurityStatus),
+				x.DamageDone,
+				x.FinalBlow,
+			},
+			color: a.highlightColor(x.CharacterId, x.CorporationId, x.AllianceId),
+		})
+	}
+
+	slices.SortStableFunc(items, func(x, y item) int {
+		return cmp.Compare(x.slot, y.slot)
+	})
+	itemTable := &table{headers: []string{"Slot", "TypeID", "TypeName", "Destroyed", "Dropped"}}
+	for _, x := range items {
+		itemTable.rows = append(itemTable.rows, tableRow{
+			values: []any{slotNames[x.slot], x.typeID, names[x.typeID], x.destroyed, x.dropped},
+		})
+	}
+	if bar != nil {
+		bar.Clear()
+	}
+	return a.printResults([]result{
+		{fmt.Sprintf("Killmail %d", km.KillmailId), victim},
+		{fmt.Sprintf("Attackers (%d)", len(attackers)), attackerTable},
+		{fmt.Sprintf("Items (%d)", len(items)), itemTable},
+	})
+}
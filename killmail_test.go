@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKillmailArgs(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		id   int32
+		hash string
+		ok   bool
+	}{
+		{"id and hash", []string{"128437264", "7f3bc6a5"}, 128437264, "7f3bc6a5", true},
+		{"ESI URL", []string{"https://esi.evetech.net/latest/killmails/128437264/7f3bc6a5/"}, 128437264, "7f3bc6a5", true},
+		{"ESI URL without trailing slash", []string{"https://esi.evetech.net/v1/killmails/128437264/7f3bc6a5"}, 128437264, "7f3bc6a5", true},
+		{"zKillboard URL and hash", []string{"https://zkillboard.com/kill/128437264/", "7f3bc6a5"}, 128437264, "7f3bc6a5", true},
+		{"zKillboard URL without hash", []string{"https://zkillboard.com/kill/128437264/"}, 0, "", false},
+		{"invalid ID", []string{"abc", "7f3bc6a5"}, 0, "", false},
+		{"ID out of range", []string{"4294967297", "7f3bc6a5"}, 0, "", false},
+		{"no args", nil, 0, "", false},
+		{"too many args", []string{"1", "2", "3"}, 0, "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, hash, err := parseKillmailArgs(tc.args)
+			if !tc.ok {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.id, id)
+				assert.Equal(t, tc.hash, hash)
+			}
+		})
+	}
+}
+
+func TestKillmailItemSlot(t *testing.T) {
+	cases := []struct {
+		flag int32
+		want int
+	}{
+		{5, slotCargo},
+		{11, slotLow},
+		{19, slotMedium},
+		{27, slotHigh},
+		{87, slotDrone},
+		{92, slotRig},
+		{125, slotSubsystem},
+		{133, slotCargo},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, killmailItemSlot(tc.flag), "flag %d", tc.flag)
+	}
+}
+
+func TestApp_RunKillmail(t *testing.T) {
+	entities := []entity{
+		{90000001, "Victim Pilot", "character"},
+		{90000002, "Attacker One", "character"},
+		{90000003, "Attacker Two", "character"},
+		{98000001, "Victim Corp", "corporation"},
+		{98000002, "Attacker Corp", "corporation"},
+		{99000001, "Victim Alliance", "alliance"},
+		{603, "Merlin", "inventory_type"},
+		{587, "Rifter", "inventory_type"},
+		{3178, "Light Neutron Blaster II", "inventory_type"},
+		{2048, "Damage Control II", "inventory_type"},
+		{23009, "Caldari Navy Antimatter Charge S", "inventory_type"},
+		{3651, "Secure Container", "inventory_type"},
+		{34, "Tritanium", "inventory_type"},
+		{30000001, "Alpha", "solar_system"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	namesURL := `=~^https://esi\.evetech\.net/v\d+/universe/names/`
+	httpmock.RegisterResponder("POST", namesURL, makeUniverseNamesEndpoint(entities))
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/killmails/128437264/abc123/`,
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"killmail_id":     128437264,
+			"killmail_time":   "2026-10-01T12:34:56Z",
+			"solar_system_id": 30000001,
+			"victim": map[string]any{
+				"character_id":   90000001,
+				"corporation_id": 98000001,
+				"alliance_id":    99000001,
+				"ship_type_id":   603,
+				"damage_taken":   2500,
+				"items": []map[string]any{
+					{"item_type_id": 3651, "flag": 5, "quantity_dropped": 1, "singleton": 0, "items": []map[string]any{
+						{"item_type_id": 34, "flag": 0, "quantity_destroyed": 1000, "singleton": 0},
+					}},
+					{"item_type_id": 3178, "flag": 27, "quantity_destroyed": 1, "singleton": 0},
+					{"item_type_id": 2048, "flag": 11, "quantity_dropped": 1, "singleton": 0},
+				},
+			},
+			"attackers": []map[string]any{
+				{"character_id": 90000003, "corporation_id": 98000002, "ship_type_id": 587, "weapon_type_id": 587, "damage_done": 500, "final_blow": true, "security_status": -2.1},
+				{"character_id": 90000002, "corporation_id": 98000002, "ship_type_id": 587, "weapon_type_id": 587, "damage_done": 2000, "final_blow": false, "security_status": 5},
+			},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/systems/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			30000001: {"constellation_id": 20000001, "name": "Alpha", "security_status": -0.3},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/constellations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			20000001: {"name": "Constellation", "region_id": 10000001},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/regions/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			10000001: {"name": "Region"},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show killmail", func(t *testing.T) {
+		st.MustClear()
+		httpmock.ZeroCallCounters()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunKillmail([]string{"128437264", "abc123"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Equal(t, 1, httpmock.GetCallCountInfo()["POST "+namesURL])
+		assert.Contains(t, got, "Killmail 128437264:")
+		assert.Regexp(t, `128437264\s+│ 2026-10-01 12:34:56\s+│ Victim Pilot\s+│ Victim Corp\s+│ Victim Alliance\s+│\s+│ Merlin\s+│ Alpha\s+│ -0.3\s+│ Region\s+│ 2500\s+│ 2`, got)
+		assert.Contains(t, got, "Attackers (2):")
+		assert.Regexp(t, `(?s)`+
+			`Attacker One\s+│ Attacker Corp\s+│\s+│\s+│ Rifter\s+│ Rifter\s+│ 5.0\s+│ 2000\s+│ false.+`+
+			`Attacker Two\s+│ Attacker Corp\s+│\s+│\s+│ Rifter\s+│ Rifter\s+│ -2.1\s+│ 500\s+│ true`, got)
+		assert.Contains(t, got, "Items (4):")
+		assert.Regexp(t, `(?s)`+
+			`High\s+│ 3178\s+│ Light Neutron Blaster II\s+│ 1\s+│ 0.+`+
+			`Low\s+│ 2048\s+│ Damage Control II\s+│ 0\s+│ 1.+`+
+			`Cargo\s+│ 3651\s+│ Secure Container\s+│ 0\s+│ 1.+`+
+			`Cargo\s+│ 34\s+│ Tritanium\s+│ 1000\s+│ 0`, got)
+	})
+	t.Run("can show killmail from ESI URL", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunKillmail([]string{"https://esi.evetech.net/latest/killmails/128437264/abc123/"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Contains(t, buf.String(), "Victim Pilot")
+	})
+	t.Run("should highlight attackers on the watchlist", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Watchlist = []WatchlistEntry{{Value: "90000003", Standing: -10}}
+		err := a.RunKillmail([]string{"128437264", "abc123"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, colorize("Attacker Two", colorRed))
+		assert.NotContains(t, got, colorize("Attacker One", colorRed))
+	})
+}
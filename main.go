@@ -2,6 +2,7 @@
 package main
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"log/slog"
 	"maps"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"time"
@@ -32,6 +34,26 @@ const (
 	sourceURL         = "https://github.com/ErikKalkoken/elt"
 )
 
+// Commands
+const (
+	commandAppraise = "appraise"
+	commandBrowse   = "browse"
+	commandFit      = "fit"
+	commandFW       = "fw"
+	commandHistory  = "history"
+	commandJumps    = "jumps"
+	commandKillmail = "killmail"
+	commandMembers  = "members"
+	commandRange    = "range"
+	commandRoute    = "route"
+	commandSov      = "sov"
+)
+
+var commands = []string{commandAppraise, commandBrowse, commandFit, commandFW, commandHistory, commandJumps, commandKillmail, commandMembers, commandRange, commandRoute, commandSov}
+
+// stdinCommands are commands which read their values from stdin when none are given.
+var stdinCommands = []string{commandAppraise, commandFit}
+
 var ErrNotFound = errors.New("not found")
 
 // Version is overwritten in the CI release process.
@@ -55,35 +77,77 @@ func main() {
 	if err != nil {
 		exitWithError(err)
 	}
-	if err := run(os.Args, os.Stdin, os.Stdout, width, dbFilePath, logFilePath); err != nil {
+	watchlistFilePath := filepath.Join(xdg.ConfigHome, appName, "watchlist.txt")
+	if err := run(os.Args, os.Stdin, os.Stdout, width, dbFilePath, logFilePath, watchlistFilePath); err != nil {
 		exitWithError(err)
 	}
 }
 
-func run(args []string, _ io.Reader, stdout io.Writer, width int, dbFilepath, logFilePath string) error {
+func run(args []string, stdin io.Reader, stdout io.Writer, width int, dbFilepath, logFilePath, watchlistFilePath string) error {
 	fs := pflag.NewFlagSet(args[0], pflag.ExitOnError)
+	activity := fs.Bool("activity", false, "show the kills and jumps of solar systems in the last hour")
 	category := fs.StringP("category", "c", "", "limit results to a category")
+	columns := fs.StringSlice("columns", nil, "show only these columns in this order, e.g. id,name,ticker")
 	clearCache := fs.Bool("clear-cache", false, "clear the local cache before the lookup")
+	detail := fs.BoolP("detail", "d", false, "show each object as card with all details")
+	expand := fs.Bool("expand", false, "also list the constellations and solar systems of regions and constellations")
+	avoid := fs.StringSlice("avoid", nil, "solar systems to avoid for routes")
+	marketFlag := fs.String("market", "", "show best buy and sell prices at this trade hub or region, e.g. jita or \"The Forge\"")
 	noSpinner := fs.Bool("no-spinner", false, "do not show spinner")
+	jumps := fs.Int("jumps", 5, "maximum number of jumps for the range of a solar system")
 	logLevel := fs.StringP("log-level", "l", logLevelDefault, "set the log level for the current run")
 	maxWidth := fs.IntP("max-width", "w", width, "set the maximum width manually. 0 = unlimited")
 	showVersion := fs.BoolP("version", "v", false, "print the version")
 	showFiles := fs.Bool("files", false, "show path to files created by elt")
+	prices := fs.Bool("prices", false, "show the market prices of inventory types")
+	prefer := fs.String("prefer", routeShortest, "security preference for routes: shortest, secure or insecure")
+	sortKey := fs.String("sort", "", "sort the rows by this column, e.g. name or -members for descending order")
+	summary := fs.BoolP("summary", "s", false, "show characters grouped by alliance and corporation")
+	watchlist := fs.String("watchlist", watchlistFilePath, "highlight entities from this watchlist file")
 	fs.Usage = func() {
 		fmt.Fprintf(os.Stderr, `Usage:
   elt [options] value [value ...]
+  elt [options] -
+  elt [options] command value [value ...]
 
 Description:
   This command looks up EVE Online objects from the game server and prints them in the terminal.
+  When the only value is "-" the values are read from stdin, one per line (e.g. a pasted Local list).
   For more information please see this website: `+sourceURL+`
 
+Commands:
+  appraise   show the volume and value of items pasted from the inventory or a cargo scan (reads stdin)
+  browse     show the child groups and types of market groups
+  fit        show the items of a fitting in EFT format grouped by slot (reads stdin)
+  fw         show faction warfare statistics and contested systems
+  history    show the corporation history of characters and the alliance history of corporations
+  jumps      show the shortest path between two solar systems and their distance in light years
+  killmail   show the victim, attackers and items of a killmail by ID and hash or URL
+  members    show the member corporations of alliances
+  range      show all solar systems within a number of jumps of a solar system
+  route      show the route between two solar systems
+  sov        show the solar systems held by alliances
+
 Options:
 `)
 		fs.PrintDefaults()
 		fmt.Fprintln(os.Stderr, `
 Examples:
   elt 30000142
-  elt "Erik Kalkoken" 603`)
+  elt "Erik Kalkoken" 603
+  elt --summary - < local.txt
+  elt appraise --market jita < items.txt
+  elt fit --prices < merlin.txt
+  elt history "Erik Kalkoken"
+  elt history "The Congregation"
+  elt killmail 128437264 7f3bc6a5ef64f8b0c6e6e4d3a0d4a3c9e9c2b1f0
+  elt members "RAPID HEAVY ROPERS"
+  elt sov "Goonswarm Federation"
+  elt fw
+  elt browse market-group 4
+  elt route --prefer secure Jita Amarr
+  elt jumps Jita Amamake
+  elt range --jumps 3 Amamake`)
 	}
 	if err := fs.Parse(args[1:]); err != nil {
 		return err
@@ -95,6 +159,7 @@ Examples:
 	if *showFiles {
 		fmt.Fprintf(stdout, "Cache: %s\n", dbFilepath)
 		fmt.Fprintf(stdout, "Log: %s\n", logFilePath)
+		fmt.Fprintf(stdout, "Watchlist: %s\n", *watchlist)
 		return nil
 	}
 	// Set log level
@@ -122,13 +187,17 @@ Examples:
 		validCategories := map[EveEntityCategory]struct{}{
 			CategoryAgent:         {},
 			CategoryAlliance:      {},
+			CategoryAsteroidBelt:  {},
 			CategoryCharacter:     {},
 			CategoryConstellation: {},
 			CategoryCorporation:   {},
 			CategoryFaction:       {},
 			CategoryInventoryType: {},
+			CategoryMoon:          {},
+			CategoryPlanet:        {},
 			CategoryRegion:        {},
 			CategorySolarSystem:   {},
+			CategoryStargate:      {},
 			CategoryStation:       {},
 		}
 		if _, ok := validCategories[EveEntityCategory(*category)]; !ok {
@@ -163,12 +232,42 @@ Examples:
 	a.MaxWidth = *maxWidth
 	a.SpinnerDisabled = *noSpinner
 	a.EntityCategory = EveEntityCategory(*category)
+	a.Columns = *columns
+	a.Detail = *detail
+	a.Expand = *expand
+	a.Activity = *activity
+	a.Prices = *prices
+	a.Market = *marketFlag
+	a.RouteAvoid = *avoid
+	a.RoutePreference = *prefer
+	a.RangeJumps = *jumps
+	a.Sort = *sortKey
+	a.Summary = *summary
+	if *watchlist != "" {
+		entries, err := loadWatchlist(*watchlist)
+		if err != nil {
+			return err
+		}
+		a.Watchlist = entries
+	}
 
 	if fs.NArg() == 0 {
 		fs.Usage()
 		return nil
 	}
 
+	var command string
+	values := fs.Args()
+	if slices.Contains(commands, values[0]) {
+		command, values = values[0], values[1:]
+	}
+	if len(values) == 1 && values[0] == "-" || slices.Contains(stdinCommands, command) && len(values) == 0 {
+		values, err = readValues(stdin)
+		if err != nil {
+			return err
+		}
+	}
+
 	if *clearCache {
 		n, err := st.Clear()
 		if err != nil {
@@ -177,10 +276,66 @@ Examples:
 		fmt.Fprintf(stdout, "cache cleared (%d objects)\n", n)
 	}
 
-	err = a.Run(fs.Args())
+	switch command {
+	case commandAppraise:
+		err = a.RunAppraise(values)
+	case commandBrowse:
+		err = a.RunBrowse(values)
+	case commandFit:
+		err = a.RunFit(values)
+	case commandFW:
+		err = a.RunFW(values)
+	case commandHistory:
+		err = a.RunHistory(values)
+	case commandJumps:
+		err = a.RunJumps(values)
+	case commandKillmail:
+		err = a.RunKillmail(values)
+	case commandMembers:
+		err = a.RunMembers(values)
+	case commandRange:
+		err = a.RunRange(values)
+	case commandRoute:
+		err = a.RunRoute(values)
+	case commandSov:
+		err = a.RunSov(values)
+	default:
+		err = a.Run(values)
+	}
 	if err != nil {
 		slog.Error("Run failed", "error", err)
 		return err // also need to tell the user about the error
 	}
 	return nil
 }
+
+// readValues returns the non-empty lines from r as values.
+func readValues(r io.Reader) ([]string, error) {
+	var values []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		v := strings.TrimSpace(scanner.Text())
+		if v == "" {
+			continue
+		}
+		values = append(values, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// loadWatchlist returns the entries of the watchlist file at path.
+// It returns no entries when the file does not exist.
+func loadWatchlist(path string) ([]WatchlistEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseWatchlist(f)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// RunMembers shows the member corporations of alliances.
+func (a App) RunMembers(args []string) error {
+	ids, names, err := a.parseValues(args)
+	if err != nil {
+		return err
+	}
+	standings, err := a.resolveWatchlist()
+	if err != nil {
+		return err
+	}
+	a.standings = standings
+	bar := a.newSpinner(fmt.Sprintf("Fetching members for %d IDs/names ...", len(ids)+len(names)))
+	entities, err := a.resolveValues(ids, names)
+	if err != nil {
+		return err
+	}
+	var results []result
+	var unsupported []EveEntity
+	for _, e := range entities {
+		if e.Category != CategoryAlliance {
+			unsupported = append(unsupported, e)
+			continue
+		}
+		t, pilots, err := a.buildMembersTable(e.ID())
+		if err != nil {
+			return err
+		}
+		title := fmt.Sprintf("Members of %s (%d corporations, %d pilots)", e.Name, len(t.rows), pilots)
+		results = append(results, result{title, t})
+	}
+	if bar != nil {
+		bar.Clear()
+	}
+	for _, e := range unsupported {
+		fmt.Fprintf(a.out, "Not an alliance: %s\n", entityDisplayName(e))
+	}
+	return a.printResults(results)
+}
+
+// buildMembersTable returns a table with the member corporations of an alliance
+// sorted by member count in descending order and the total number of pilots.
+func (a App) buildMembersTable(allianceID int32) (*table, int, error) {
+	corporationIDs, _, err := a.esiClient.ESI.AllianceApi.GetAlliancesAllianceIdCorporations(context.Background(), allianceID, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	corporations, err := a.fetchCorporations(corporationIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	slices.SortFunc(corporations, func(x, y EveCorporation) int {
+		return cmp.Or(cmp.Compare(y.MemberCount, x.MemberCount), cmp.Compare(x.ID(), y.ID()))
+	})
+	var pilots int
+	t := &table{headers: []string{"ID", "Name", "Ticker", "Members"}}
+	for _, o := range corporations {
+		pilots += int(o.MemberCount)
+		t.rows = append(t.rows, tableRow{
+			values: []any{o.ID(), o.Name, o.Ticker, o.MemberCount},
+			color:  a.highlightColor(o.ID(), o.AllianceID),
+		})
+	}
+	return t, pilots, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_RunMembers(t *testing.T) {
+	entities := []entity{
+		{1001, "Alpha", "character"},
+		{99000001, "RAPID HEAVY ROPERS", "alliance"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint(entities),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/alliances/99000001/corporations/`,
+		httpmock.NewJsonResponderOrPanic(200, []int32{98000001, 98000002}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/corporations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			98000001: {"name": "Ropers Inc", "ticker": "ROPE1", "member_count": 20, "alliance_id": 99000001},
+			98000002: {"name": "Ropers Two", "ticker": "ROPE2", "member_count": 35, "alliance_id": 99000001},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show member corporations of an alliance", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunMembers([]string{"99000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Members of RAPID HEAVY ROPERS (2 corporations, 55 pilots):")
+		assert.Regexp(t, `(?s)Ropers Two.+Ropers Inc`, got)
+	})
+	t.Run("should report entities which are not alliances", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunMembers([]string{"1001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Contains(t, buf.String(), "Not an alliance: Alpha")
+	})
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -15,6 +17,57 @@ const (
 	npcCorporationIDEnd   = 2_000_000
 	npcCharacterIDBegin   = 3_000_000
 	npcCharacterIDEnd     = 4_000_000
+	celestialIDBegin      = 40_000_000
+	celestialIDEnd        = 50_000_000
+	stargateIDBegin       = 50_000_000
+	stargateIDEnd         = 60_000_000
+	lightYear             = 9_460_730_472_580_800 // in meters
+)
+
+// affiliated is implemented by Eve objects which belong to other Eve objects, e.g. a character to a corporation.
+type affiliated interface {
+	// AffiliationIDs returns the IDs of the objects this object belongs to.
+	AffiliationIDs() []int32
+}
+
+// age represents the age of an object, e.g. of a character.
+type age time.Duration
+
+// String returns the age in years, months and days, e.g. "2y 3m".
+func (x age) String() string {
+	if x <= 0 {
+		return ""
+	}
+	days := int(time.Duration(x) / day)
+	years := days / 365
+	months := (days % 365) / 30
+	switch {
+	case years > 0:
+		return fmt.Sprintf("%dy %dm", years, months)
+	case months > 0:
+		return fmt.Sprintf("%dm %dd", months, (days%365)%30)
+	}
+	return fmt.Sprintf("%dd", days)
+}
+
+// position represents a position in space in meters.
+type position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+func (p position) distance(other position) float64 {
+	return math.Sqrt(math.Pow(p.X-other.X, 2) + math.Pow(p.Y-other.Y, 2) + math.Pow(p.Z-other.Z, 2))
+}
+
+type securityBand string
+
+// Security bands of solar systems
+const (
+	securityHigh securityBand = "high"
+	securityLow  securityBand = "low"
+	securityNull securityBand = "null"
 )
 
 type EveEntityCategory string
@@ -24,13 +77,17 @@ const (
 	CategoryUndefined     EveEntityCategory = ""
 	CategoryAgent         EveEntityCategory = "agent"
 	CategoryAlliance      EveEntityCategory = "alliance"
+	CategoryAsteroidBelt  EveEntityCategory = "asteroid_belt"
 	CategoryCharacter     EveEntityCategory = "character"
 	CategoryConstellation EveEntityCategory = "constellation"
 	CategoryCorporation   EveEntityCategory = "corporation"
 	CategoryFaction       EveEntityCategory = "faction"
 	CategoryInventoryType EveEntityCategory = "inventory_type"
+	CategoryMoon          EveEntityCategory = "moon"
+	CategoryPlanet        EveEntityCategory = "planet"
 	CategoryRegion        EveEntityCategory = "region"
 	CategorySolarSystem   EveEntityCategory = "solar_system"
+	CategoryStargate      EveEntityCategory = "stargate"
 	CategoryStation       EveEntityCategory = "station"
 	CategoryInvalid       EveEntityCategory = "invalid"
 	CategoryUnknown       EveEntityCategory = "unknown" // CategoryUnknown represents a new or changed category
@@ -60,7 +117,7 @@ func (o EveEntity) ID() int32 {
 
 func (o EveEntity) IsStale() bool {
 	switch o.Category {
-	case CategoryInventoryType:
+	case CategoryAsteroidBelt, CategoryInventoryType, CategoryMoon, CategoryPlanet, CategoryStargate:
 		return o.Timestamp.Before(time.Now().UTC().Add(-week))
 	default:
 		return o.Timestamp.Before(time.Now().UTC().Add(-day))
@@ -72,10 +129,15 @@ func (o EveEntity) IsValid() bool {
 }
 
 type EveAlliance struct {
-	AllianceID int32     `json:"alliance_id"`
-	Name       string    `json:"name"`
-	Ticker     string    `json:"ticker"`
-	Timestamp  time.Time `json:"timestamp"`
+	AllianceID            int32     `json:"alliance_id"`
+	CreatorCorporationID  int32     `json:"creator_corporation_id"`
+	CreatorID             int32     `json:"creator_id"`
+	DateFounded           time.Time `json:"date_founded"`
+	ExecutorCorporationID int32     `json:"executor_corporation_id"`
+	FactionID             int32     `json:"faction_id"`
+	Name                  string    `json:"name"`
+	Ticker                string    `json:"ticker"`
+	Timestamp             time.Time `json:"timestamp"`
 }
 
 func (o EveAlliance) ID() int32 {
@@ -90,6 +152,45 @@ func (o EveAlliance) IsValid() bool {
 	return o.ID() != 0
 }
 
+type EveAsteroidBelt struct {
+	AsteroidBeltID int32     `json:"asteroid_belt_id"`
+	Name           string    `json:"name"`
+	SolarSystemID  int32     `json:"system_id"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+func (o EveAsteroidBelt) ID() int32 {
+	return o.AsteroidBeltID
+}
+
+func (o EveAsteroidBelt) IsStale() bool {
+	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+}
+
+func (o EveAsteroidBelt) IsValid() bool {
+	return o.ID() != 0
+}
+
+type EveBloodline struct {
+	BloodlineID   int32     `json:"bloodline_id"`
+	CorporationID int32     `json:"corporation_id"`
+	Name          string    `json:"name"`
+	RaceID        int32     `json:"race_id"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+func (o EveBloodline) ID() int32 {
+	return o.BloodlineID
+}
+
+func (o EveBloodline) IsStale() bool {
+	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+}
+
+func (o EveBloodline) IsValid() bool {
+	return o.ID() != 0
+}
+
 type EveCategory struct {
 	CategoryID int32     `json:"category_id"`
 	Name       string    `json:"name"`
@@ -110,11 +211,20 @@ func (o EveCategory) IsValid() bool {
 }
 
 type EveCharacter struct {
-	AllianceID    int32     `json:"alliance_id"`
-	CharacterID   int32     `json:"character_id"`
-	CorporationID int32     `json:"corporation_id"`
-	Name          string    `json:"name"`
-	Timestamp     time.Time `json:"timestamp"`
+	AllianceID       int32     `json:"alliance_id"`
+	Birthday         time.Time `json:"birthday"`
+	BloodlineID      int32     `json:"bloodline_id"`
+	CharacterID      int32     `json:"character_id"`
+	CorporationID    int32     `json:"corporation_id"`
+	Description      string    `json:"description"`
+	DetailsTimestamp time.Time `json:"details_timestamp"` // when the full character was last fetched. Timestamp also changes with affiliations.
+	FactionID        int32     `json:"faction_id"`
+	Gender           string    `json:"gender"`
+	Name             string    `json:"name"`
+	RaceID           int32     `json:"race_id"`
+	SecurityStatus   float32   `json:"security_status"`
+	Timestamp        time.Time `json:"timestamp"`
+	Title            string    `json:"title"`
 }
 
 func (o EveCharacter) ID() int32 {
@@ -122,7 +232,26 @@ func (o EveCharacter) ID() int32 {
 }
 
 func (o EveCharacter) IsStale() bool {
-	return o.Timestamp.Before(time.Now().UTC().Add(-day))
+	return o.Timestamp.Before(time.Now().UTC().Add(-day)) || o.HasStaleDetails()
+}
+
+// HasStaleDetails reports whether the full character needs to be fetched again,
+// e.g. because the security status or title might have changed.
+// Characters cached before details were tracked always have stale details.
+func (o EveCharacter) HasStaleDetails() bool {
+	return o.DetailsTimestamp.Before(time.Now().UTC().Add(-week))
+}
+
+// Age returns the age of a character.
+func (o EveCharacter) Age() age {
+	if o.Birthday.IsZero() {
+		return 0
+	}
+	return age(time.Since(o.Birthday))
+}
+
+func (o EveCharacter) AffiliationIDs() []int32 {
+	return []int32{o.CorporationID, o.AllianceID}
 }
 
 func (o EveCharacter) IsNPC() bool {
@@ -140,6 +269,7 @@ type EveConstellation struct {
 	ConstellationID int32     `json:"constellation_id"`
 	Name            string    `json:"name"`
 	RegionID        int32     `json:"region_id"`
+	SolarSystemIDs  []int32   `json:"system_ids"`
 	Timestamp       time.Time `json:"timestamp"`
 }
 
@@ -148,7 +278,7 @@ func (o EveConstellation) ID() int32 {
 }
 
 func (o EveConstellation) IsStale() bool {
-	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+	return o.Timestamp.Before(time.Now().UTC().Add(-week)) || o.SolarSystemIDs == nil
 }
 
 func (o EveConstellation) IsValid() bool {
@@ -159,10 +289,17 @@ type EveCorporation struct {
 	AllianceID    int32     `json:"alliance_id"`
 	CeoID         int32     `json:"ceo_id"`
 	CorporationID int32     `json:"corporation_id"`
+	CreatorID     int32     `json:"creator_id"`
+	DateFounded   time.Time `json:"date_founded"`
+	FactionID     int32     `json:"faction_id"`
+	HomeStationID int32     `json:"home_station_id"`
 	MemberCount   int32     `json:"member_count"`
 	Name          string    `json:"name"`
+	TaxRate       float32   `json:"tax_rate"`
 	Ticker        string    `json:"ticker"`
 	Timestamp     time.Time `json:"timestamp"`
+	URL           string    `json:"url"`
+	WarEligible   bool      `json:"war_eligible"`
 }
 
 func (o EveCorporation) ID() int32 {
@@ -173,6 +310,10 @@ func (o EveCorporation) IsStale() bool {
 	return o.Timestamp.Before(time.Now().UTC().Add(-day))
 }
 
+func (o EveCorporation) AffiliationIDs() []int32 {
+	return []int32{o.AllianceID}
+}
+
 func (o EveCorporation) IsNPC() bool {
 	if o.CorporationID >= npcCorporationIDBegin && o.CorporationID < npcCorporationIDEnd {
 		return true
@@ -186,9 +327,15 @@ func (o EveCorporation) IsValid() bool {
 
 type EveFaction struct {
 	CorporationID        int32     `json:"corporation_id"`
+	Description          string    `json:"description"`
 	FactionID            int32     `json:"faction_id"`
+	IsUnique             bool      `json:"is_unique"`
 	MilitiaCorporationID int32     `json:"militia_corporation_id"`
 	Name                 string    `json:"name"`
+	SizeFactor           float32   `json:"size_factor"`
+	SolarSystemID        int32     `json:"solar_system_id"`
+	StationCount         int32     `json:"station_count"`
+	StationSystemCount   int32     `json:"station_system_count"`
 	Timestamp            time.Time `json:"timestamp"`
 }
 
@@ -197,7 +344,13 @@ func (o EveFaction) ID() int32 {
 }
 
 func (o EveFaction) IsStale() bool {
-	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+	return o.Timestamp.Before(time.Now().UTC().Add(-week)) || !o.HasDetails()
+}
+
+// HasDetails reports whether the details of a faction like its description are known.
+// Invalid factions have no details.
+func (o EveFaction) HasDetails() bool {
+	return o.Description != "" || o.Name == nameInvalid
 }
 
 func (o EveFaction) IsValid() bool {
@@ -224,10 +377,69 @@ func (o EveGroup) IsValid() bool {
 	return o.ID() != 0
 }
 
+type EveMoon struct {
+	MoonID        int32     `json:"moon_id"`
+	Name          string    `json:"name"`
+	SolarSystemID int32     `json:"system_id"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+func (o EveMoon) ID() int32 {
+	return o.MoonID
+}
+
+func (o EveMoon) IsStale() bool {
+	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+}
+
+func (o EveMoon) IsValid() bool {
+	return o.ID() != 0
+}
+
+type EvePlanet struct {
+	Name          string    `json:"name"`
+	PlanetID      int32     `json:"planet_id"`
+	SolarSystemID int32     `json:"system_id"`
+	Timestamp     time.Time `json:"timestamp"`
+	TypeID        int32     `json:"type_id"`
+}
+
+func (o EvePlanet) ID() int32 {
+	return o.PlanetID
+}
+
+func (o EvePlanet) IsStale() bool {
+	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+}
+
+func (o EvePlanet) IsValid() bool {
+	return o.ID() != 0
+}
+
+type EveRace struct {
+	AllianceID int32     `json:"alliance_id"`
+	Name       string    `json:"name"`
+	RaceID     int32     `json:"race_id"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (o EveRace) ID() int32 {
+	return o.RaceID
+}
+
+func (o EveRace) IsStale() bool {
+	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+}
+
+func (o EveRace) IsValid() bool {
+	return o.ID() != 0
+}
+
 type EveRegion struct {
-	Name      string    `json:"name"`
-	RegionID  int32     `json:"region_id"`
-	Timestamp time.Time `json:"timestamp"`
+	ConstellationIDs []int32   `json:"constellation_ids"`
+	Name             string    `json:"name"`
+	RegionID         int32     `json:"region_id"`
+	Timestamp        time.Time `json:"timestamp"`
 }
 
 func (o EveRegion) ID() int32 {
@@ -235,7 +447,7 @@ func (o EveRegion) ID() int32 {
 }
 
 func (o EveRegion) IsStale() bool {
-	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+	return o.Timestamp.Before(time.Now().UTC().Add(-week)) || o.ConstellationIDs == nil
 }
 
 func (o EveRegion) IsValid() bool {
@@ -243,45 +455,196 @@ func (o EveRegion) IsValid() bool {
 }
 
 type EveType struct {
-	GroupID   int32     `json:"group_id"`
-	Name      string    `json:"name"`
-	Published bool      `json:"published"`
-	Timestamp time.Time `json:"timestamp"`
-	TypeID    int32     `json:"type_id"`
+	Capacity        float32                 `json:"capacity"`
+	Description     string                  `json:"description"`
+	DogmaAttributes []EveTypeDogmaAttribute `json:"dogma_attributes"`
+	DogmaEffects    []EveTypeDogmaEffect    `json:"dogma_effects"`
+	GroupID         int32                   `json:"group_id"`
+	IconID          int32                   `json:"icon_id"`
+	MarketGroupID   int32                   `json:"market_group_id"`
+	Mass            float32                 `json:"mass"`
+	Name            string                  `json:"name"`
+	PackagedVolume  float32                 `json:"packaged_volume"`
+	Published       bool                    `json:"published"`
+	Timestamp       time.Time               `json:"timestamp"`
+	TypeID          int32                   `json:"type_id"`
+	Volume          float32                 `json:"volume"`
 }
 
 func (o EveType) ID() int32 {
 	return o.TypeID
 }
 
+// IsStale reports whether an inventory type needs to be fetched again.
+// Types cached before dogma effects were stored have none, not even an empty list,
+// and are stale too, because they also lack their packaged volume.
 func (o EveType) IsStale() bool {
-	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+	return o.Timestamp.Before(time.Now().UTC().Add(-week)) || o.DogmaEffects == nil
 }
 
 func (o EveType) IsValid() bool {
 	return o.ID() != 0
 }
 
+// EveTypeDogmaAttribute is the value of a dogma attribute for an inventory type.
+type EveTypeDogmaAttribute struct {
+	AttributeID int32   `json:"attribute_id"`
+	Value       float32 `json:"value"`
+}
+
+// EveTypeDogmaEffect is a dogma effect of an inventory type.
+type EveTypeDogmaEffect struct {
+	EffectID  int32 `json:"effect_id"`
+	IsDefault bool  `json:"is_default"`
+}
+
+type EveMarketGroup struct {
+	Description   string    `json:"description"`
+	MarketGroupID int32     `json:"market_group_id"`
+	Name          string    `json:"name"`
+	ParentGroupID int32     `json:"parent_group_id"`
+	Timestamp     time.Time `json:"timestamp"`
+	TypeIDs       []int32   `json:"type_ids"`
+}
+
+func (o EveMarketGroup) ID() int32 {
+	return o.MarketGroupID
+}
+
+func (o EveMarketGroup) IsStale() bool {
+	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+}
+
+func (o EveMarketGroup) IsValid() bool {
+	return o.ID() != 0
+}
+
+type EveDogmaAttribute struct {
+	AttributeID  int32     `json:"attribute_id"`
+	DefaultValue float32   `json:"default_value"`
+	Description  string    `json:"description"`
+	DisplayName  string    `json:"display_name"`
+	HighIsGood   bool      `json:"high_is_good"`
+	Name         string    `json:"name"`
+	Published    bool      `json:"published"`
+	Timestamp    time.Time `json:"timestamp"`
+	UnitID       int32     `json:"unit_id"`
+}
+
+func (o EveDogmaAttribute) ID() int32 {
+	return o.AttributeID
+}
+
+func (o EveDogmaAttribute) IsStale() bool {
+	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+}
+
+func (o EveDogmaAttribute) IsValid() bool {
+	return o.ID() != 0
+}
+
+// DisplayNameOrName returns the display name of an attribute or its name when it has no display name.
+func (o EveDogmaAttribute) DisplayNameOrName() string {
+	if o.DisplayName != "" {
+		return o.DisplayName
+	}
+	return o.Name
+}
+
 type EveSolarSystem struct {
-	ConstellationID int32     `json:"constellation_id"`
-	Name            string    `json:"name"`
-	Security        float32   `json:"security"`
-	SolarSystemID   int32     `json:"system_id"`
-	Timestamp       time.Time `json:"timestamp"`
+	ConstellationID int32                  `json:"constellation_id"`
+	Name            string                 `json:"name"`
+	NeighborIDs     []int32                `json:"neighbor_ids"` // IDs of solar systems connected by stargates. Nil when not yet known.
+	Planets         []EveSolarSystemPlanet `json:"planets"`
+	Position        position               `json:"position"`
+	Security        float32                `json:"security"`
+	SolarSystemID   int32                  `json:"system_id"`
+	StarID          int32                  `json:"star_id"`
+	StargateIDs     []int32                `json:"stargate_ids"`
+	StationIDs      []int32                `json:"station_ids"`
+	Timestamp       time.Time              `json:"timestamp"`
+}
+
+// DisplaySecurity returns the security of a solar system rounded to one decimal as shown in game.
+// Systems with a security just above 0.0 are rounded up to 0.1, because they are low sec.
+func (o EveSolarSystem) DisplaySecurity() float32 {
+	if o.Security > 0 && o.Security < 0.05 {
+		return 0.1
+	}
+	s := float32(math.Round(float64(o.Security*10))) / 10 // multiply as float32, so that e.g. 0.45 rounds up
+	if s == 0 {
+		return 0 // avoid -0
+	}
+	return s
+}
+
+// SecurityBand returns the security band of a solar system, e.g. systems with a rounded security of 0.5 or higher are high sec.
+func (o EveSolarSystem) SecurityBand() securityBand {
+	switch s := o.DisplaySecurity(); {
+	case s >= 0.5:
+		return securityHigh
+	case s > 0:
+		return securityLow
+	}
+	return securityNull
+}
+
+// DistanceLY returns the direct distance to another solar system in light years.
+func (o EveSolarSystem) DistanceLY(other EveSolarSystem) float64 {
+	return o.Position.distance(other.Position) / lightYear
+}
+
+// HasNeighbors reports whether the neighbors of a solar system are known.
+// Systems without a position were cached by older versions and their stargates are not known.
+func (o EveSolarSystem) HasNeighbors() bool {
+	return o.HasPosition() && (o.NeighborIDs != nil || len(o.StargateIDs) == 0)
+}
+
+// MoonCount returns the number of moons in a solar system.
+func (o EveSolarSystem) MoonCount() int {
+	var n int
+	for _, p := range o.Planets {
+		n += len(p.MoonIDs)
+	}
+	return n
+}
+
+// AsteroidBeltCount returns the number of asteroid belts in a solar system.
+func (o EveSolarSystem) AsteroidBeltCount() int {
+	var n int
+	for _, p := range o.Planets {
+		n += len(p.AsteroidBeltIDs)
+	}
+	return n
 }
 
 func (o EveSolarSystem) ID() int32 {
 	return o.SolarSystemID
 }
 
+// IsStale reports whether a solar system needs to be fetched again.
+// Solar systems cached by older versions without a position are also stale,
+// because they might lack their stargates too.
 func (o EveSolarSystem) IsStale() bool {
-	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+	return o.Timestamp.Before(time.Now().UTC().Add(-week)) || !o.HasPosition()
+}
+
+// HasPosition reports whether the position of a solar system is known.
+func (o EveSolarSystem) HasPosition() bool {
+	return o.Position != position{}
 }
 
 func (o EveSolarSystem) IsValid() bool {
 	return o.ID() != 0
 }
 
+// EveSolarSystemPlanet represents a planet in a solar system with the moons and asteroid belts in its orbit.
+type EveSolarSystemPlanet struct {
+	AsteroidBeltIDs []int32 `json:"asteroid_belt_ids"`
+	MoonIDs         []int32 `json:"moon_ids"`
+	PlanetID        int32   `json:"planet_id"`
+}
+
 type EveStation struct {
 	Name          string    `json:"name"`
 	OwnerID       int32     `json:"owner_id"`
@@ -302,3 +665,138 @@ func (o EveStation) IsStale() bool {
 func (o EveStation) IsValid() bool {
 	return o.ID() != 0
 }
+
+type EveStar struct {
+	Age           int64     `json:"age"`
+	Luminosity    float32   `json:"luminosity"`
+	Name          string    `json:"name"`
+	Radius        int64     `json:"radius"`
+	SolarSystemID int32     `json:"system_id"`
+	SpectralClass string    `json:"spectral_class"`
+	StarID        int32     `json:"star_id"`
+	Temperature   int32     `json:"temperature"`
+	Timestamp     time.Time `json:"timestamp"`
+	TypeID        int32     `json:"type_id"`
+}
+
+func (o EveStar) ID() int32 {
+	return o.StarID
+}
+
+func (o EveStar) IsStale() bool {
+	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+}
+
+func (o EveStar) IsValid() bool {
+	return o.ID() != 0
+}
+
+type EveStargate struct {
+	DestinationSolarSystemID int32     `json:"destination_system_id"`
+	DestinationStargateID    int32     `json:"destination_stargate_id"`
+	Name                     string    `json:"name"`
+	SolarSystemID            int32     `json:"system_id"`
+	StargateID               int32     `json:"stargate_id"`
+	Timestamp                time.Time `json:"timestamp"`
+	TypeID                   int32     `json:"type_id"`
+}
+
+func (o EveStargate) ID() int32 {
+	return o.StargateID
+}
+
+func (o EveStargate) IsStale() bool {
+	return o.Timestamp.Before(time.Now().UTC().Add(-week))
+}
+
+func (o EveStargate) IsValid() bool {
+	return o.ID() != 0
+}
+
+// EveSovereignty is the sovereignty of a solar system.
+// Solar systems without any holder are stored too, so they are not fetched again.
+type EveSovereignty struct {
+	AllianceID    int32                     `json:"alliance_id"`
+	CorporationID int32                     `json:"corporation_id"`
+	FactionID     int32                     `json:"faction_id"`
+	SolarSystemID int32                     `json:"system_id"`
+	Structures    []EveSovereigntyStructure `json:"structures"`
+	Timestamp     time.Time                 `json:"timestamp"`
+}
+
+func (o EveSovereignty) ID() int32 {
+	return o.SolarSystemID
+}
+
+func (o EveSovereignty) IsStale() bool {
+	return o.Timestamp.Before(time.Now().UTC().Add(-time.Hour))
+}
+
+func (o EveSovereignty) IsValid() bool {
+	return o.ID() != 0
+}
+
+// ADM returns the highest activity defense multiplier of the sovereignty structures.
+func (o EveSovereignty) ADM() float32 {
+	var v float32
+	for _, s := range o.Structures {
+		v = max(v, s.VulnerabilityOccupancyLevel)
+	}
+	return v
+}
+
+// EveSovereigntyStructure is a sovereignty structure in a solar system.
+type EveSovereigntyStructure struct {
+	AllianceID                  int32     `json:"alliance_id"`
+	StructureID                 int64     `json:"structure_id"`
+	TypeID                      int32     `json:"type_id"`
+	VulnerabilityOccupancyLevel float32   `json:"vulnerability_occupancy_level"`
+	VulnerableEndTime           time.Time `json:"vulnerable_end_time"`
+	VulnerableStartTime         time.Time `json:"vulnerable_start_time"`
+}
+
+// EveSolarSystemActivity is the recent activity in a solar system.
+// It is stale once the data on the game server has expired.
+type EveSolarSystemActivity struct {
+	Expires       time.Time `json:"expires"`
+	Jumps         int32     `json:"jumps"`
+	NPCKills      int32     `json:"npc_kills"`
+	PodKills      int32     `json:"pod_kills"`
+	ShipKills     int32     `json:"ship_kills"`
+	SolarSystemID int32     `json:"system_id"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+func (o EveSolarSystemActivity) ID() int32 {
+	return o.SolarSystemID
+}
+
+func (o EveSolarSystemActivity) IsStale() bool {
+	return o.Expires.Before(time.Now().UTC())
+}
+
+func (o EveSolarSystemActivity) IsValid() bool {
+	return o.ID() != 0
+}
+
+// EveMarketPrice is the average and adjusted price of an inventory type across all markets.
+// It is stale once the data on the game server has expired.
+type EveMarketPrice struct {
+	AdjustedPrice float64   `json:"adjusted_price"`
+	AveragePrice  float64   `json:"average_price"`
+	Expires       time.Time `json:"expires"`
+	Timestamp     time.Time `json:"timestamp"`
+	TypeID        int32     `json:"type_id"`
+}
+
+func (o EveMarketPrice) ID() int32 {
+	return o.TypeID
+}
+
+func (o EveMarketPrice) IsStale() bool {
+	return o.Expires.Before(time.Now().UTC())
+}
+
+func (o EveMarketPrice) IsValid() bool {
+	return o.ID() != 0
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAge(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, ""},
+		{3 * day, "3d"},
+		{45 * day, "1m 15d"},
+		{(2*365 + 95) * day, "2y 3m"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, tc.want, age(tc.d).String())
+		})
+	}
+}
+
+func TestEveSolarSystem_DisplaySecurity(t *testing.T) {
+	cases := []struct {
+		security float32
+		want     float32
+	}{
+		{0.96, 1.0},
+		{0.45, 0.5},
+		{0.44, 0.4},
+		{0.04, 0.1},
+		{0.0, 0.0},
+		{-0.04, 0.0},
+		{-0.56, -0.6},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprint(tc.security), func(t *testing.T) {
+			o := EveSolarSystem{Security: tc.security}
+			assert.InDelta(t, tc.want, o.DisplaySecurity(), 0.0001)
+		})
+	}
+}
+
+func TestEveSolarSystem_SecurityBand(t *testing.T) {
+	cases := []struct {
+		security float32
+		want     securityBand
+	}{
+		{1.0, securityHigh},
+		{0.45, securityHigh},
+		{0.44, securityLow},
+		{0.05, securityLow},
+		{0.04, securityLow},
+		{0.01, securityLow},
+		{0.0, securityNull},
+		{-0.04, securityNull},
+		{-1.0, securityNull},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprint(tc.security), func(t *testing.T) {
+			o := EveSolarSystem{Security: tc.security}
+			assert.Equal(t, tc.want, o.SecurityBand())
+		})
+	}
+}
+
+func TestEveType_IsStale(t *testing.T) {
+	t.Run("should not be stale when recently fetched", func(t *testing.T) {
+		o := EveType{DogmaEffects: []EveTypeDogmaEffect{}, Timestamp: time.Now().UTC()}
+		assert.False(t, o.IsStale())
+	})
+	t.Run("should be stale when cached without dogma effects", func(t *testing.T) {
+		o := EveType{Timestamp: time.Now().UTC()}
+		assert.True(t, o.IsStale())
+	})
+}
+
+func TestEveConstellation_IsStale(t *testing.T) {
+	t.Run("should not be stale when recently fetched", func(t *testing.T) {
+		o := EveConstellation{SolarSystemIDs: []int32{}, Timestamp: time.Now().UTC()}
+		assert.False(t, o.IsStale())
+	})
+	t.Run("should be stale when cached without solar systems", func(t *testing.T) {
+		o := EveConstellation{Timestamp: time.Now().UTC()}
+		assert.True(t, o.IsStale())
+	})
+}
+
+func TestEveRegion_IsStale(t *testing.T) {
+	t.Run("should not be stale when recently fetched", func(t *testing.T) {
+		o := EveRegion{ConstellationIDs: []int32{}, Timestamp: time.Now().UTC()}
+		assert.False(t, o.IsStale())
+	})
+	t.Run("should be stale when cached without constellations", func(t *testing.T) {
+		o := EveRegion{Timestamp: time.Now().UTC()}
+		assert.True(t, o.IsStale())
+	})
+}
+
+func TestEveFaction_IsStale(t *testing.T) {
+	t.Run("should not be stale when recently fetched", func(t *testing.T) {
+		o := EveFaction{Description: "dummy", Timestamp: time.Now().UTC()}
+		assert.False(t, o.IsStale())
+	})
+	t.Run("should not be stale when invalid", func(t *testing.T) {
+		o := EveFaction{Name: nameInvalid, Timestamp: time.Now().UTC()}
+		assert.False(t, o.IsStale())
+	})
+	t.Run("should be stale when cached without details", func(t *testing.T) {
+		o := EveFaction{Name: "Caldari State", Timestamp: time.Now().UTC()}
+		assert.True(t, o.IsStale())
+	})
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antihax/goesi/esi"
+	"github.com/antihax/goesi/optional"
+	"golang.org/x/sync/errgroup"
+)
+
+// pricesExpiryDefault is used when the game server does not report when the prices expire.
+const pricesExpiryDefault = time.Hour
+
+// market is a location for buying and selling items.
+// It is either a trade hub station or a whole region when the station ID is 0.
+type market struct {
+	regionID  int32
+	stationID int64
+}
+
+// marketHubs are the main trade hubs by their lower case name.
+var marketHubs = map[string]market{
+	"amarr":   {10000043, 60008494}, // Amarr VIII (Oris) - Emperor Family Academy
+	"dodixie": {10000032, 60011866}, // Dodixie IX - Moon 20 - Federation Navy Assembly Plant
+	"hek":     {10000042, 60005686}, // Hek VIII - Moon 12 - Boundless Creation Factory
+	"jita":    {10000002, 60003760}, // Jita IV - Moon 4 - Caldari Navy Assembly Plant
+	"rens":    {10000030, 60004588}, // Rens VI - Moon 8 - Brutor Tribe Treasury
+}
+
+// bestPrice is the highest buy and lowest sell price of an inventory type on a market.
+type bestPrice struct {
+	buy  float64
+	sell float64
+}
+
+var priceHeaders = []string{"AveragePrice", "AdjustedPrice"}
+
+var bestPriceHeaders = []string{"BestBuy", "BestSell"}
+
+// priceColumns adds the optional price columns to a type table.
+type priceColumns struct {
+	prices     map[int32]EveMarketPrice
+	bestPrices map[int32]bestPrice // nil when no market was chosen
+	enabled    bool
+}
+
+func (pc priceColumns) headers(headers []string) []string {
+	if !pc.enabled {
+		return headers
+	}
+	headers = slices.Concat(headers, priceHeaders)
+	if pc.bestPrices != nil {
+		headers = slices.Concat(headers, bestPriceHeaders)
+	}
+	return headers
+}
+
+func (pc priceColumns) values(typeID int32, values []any) []any {
+	if !pc.enabled {
+		return values
+	}
+	p := pc.prices[typeID]
+	values = append(values, formatISK(p.AveragePrice), formatISK(p.AdjustedPrice))
+	if pc.bestPrices != nil {
+		bp := pc.bestPrices[typeID]
+		values = append(values, formatISK(bp.buy), formatISK(bp.sell))
+	}
+	return values
+}
+
+// makeTypePriceColumns returns the price columns for inventory types.
+// Prices are shown when requested or when a market is chosen.
+func (a App) makeTypePriceColumns(typeIDs []int32) (priceColumns, error) {
+	if !a.Prices && a.Market == "" {
+		return priceColumns{}, nil
+	}
+	prices, err := a.fetchMarketPrices(typeIDs)
+	if err != nil {
+		return priceColumns{}, err
+	}
+	pc := priceColumns{prices: prices, enabled: true}
+	if a.Market != "" {
+		m, err := a.resolveMarket(a.Market)
+		if err != nil {
+			return priceColumns{}, err
+		}
+		pc.bestPrices, err = a.fetchBestPrices(m, typeIDs)
+		if err != nil {
+			return priceColumns{}, err
+		}
+	}
+	return pc, nil
+}
+
+// resolveMarket returns the market for a trade hub name or a region given as ID or name.
+func (a App) resolveMarket(value string) (market, error) {
+	if m, ok := marketHubs[strings.ToLower(value)]; ok {
+		return m, nil
+	}
+	ids, names, err := a.parseValues([]string{value})
+	if err != nil {
+		return market{}, err
+	}
+	entities, err := a.resolveValues(ids, names)
+	if err != nil {
+		return market{}, err
+	}
+	for _, e := range entities {
+		if e.Category == CategoryRegion {
+			return market{regionID: e.ID()}, nil
+		}
+	}
+	hubs := slices.Sorted(maps.Keys(marketHubs))
+	return market{}, fmt.Errorf("not a trade hub or region: %s. Valid trade hubs are: %s", value, strings.Join(hubs, ", "))
+}
+
+// fetchMarketPrices returns the average and adjusted prices of inventory types by their ID.
+func (a App) fetchMarketPrices(typeIDs []int32) (map[int32]EveMarketPrice, error) {
+	objs, missing, err := a.st.ListFreshEveMarketPriceByID(typeIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		objs, err = a.refreshMarketPrices(missing)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return makeLookupMap(objs), nil
+}
+
+// refreshMarketPrices fetches the prices of all inventory types from ESI, stores them and returns them.
+// Additional inventory types without a price are stored too.
+func (a App) refreshMarketPrices(typeIDs []int32) ([]EveMarketPrice, error) {
+	data, r, err := a.esiClient.ESI.MarketApi.GetMarketsPrices(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	expires := responseExpires(r, now().Add(pricesExpiryDefault))
+	prices := make(map[int32]EveMarketPrice)
+	for _, x := range data {
+		prices[x.TypeId] = EveMarketPrice{AdjustedPrice: x.AdjustedPrice, AveragePrice: x.AveragePrice}
+	}
+	for _, id := range typeIDs {
+		if _, ok := prices[id]; !ok {
+			prices[id] = EveMarketPrice{}
+		}
+	}
+	objs := make([]EveMarketPrice, 0, len(prices))
+	for id, o := range prices {
+		o.Expires = expires
+		o.Timestamp = now()
+		o.TypeID = id
+		objs = append(objs, o)
+	}
+	if err := a.st.UpdateOrCreateEveMarketPrice(objs); err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+// fetchBestPrices returns the best buy and sell prices of inventory types on a market by type ID.
+func (a App) fetchBestPrices(m market, typeIDs []int32) (map[int32]bestPrice, error) {
+	prices := make(map[int32]bestPrice)
+	var mu sync.Mutex
+	g := new(errgroup.Group)
+	g.SetLimit(maxConcurrentRequests)
+	for _, id := range sliceUnique(typeIDs) {
+		g.Go(func() error {
+			orders, err := a.fetchMarketOrders(m.regionID, id)
+			if err != nil {
+				return err
+			}
+			var p bestPrice
+			for _, o := range orders {
+				if m.stationID != 0 && o.LocationId != m.stationID {
+					continue
+				}
+				if o.IsBuyOrder {
+					p.buy = max(p.buy, o.Price)
+				} else if p.sell == 0 || o.Price < p.sell {
+					p.sell = o.Price
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			prices[id] = p
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// fetchMarketOrders returns all orders for an inventory type in a region from all pages.
+func (a App) fetchMarketOrders(regionID, typeID int32) ([]esi.GetMarketsRegionIdOrders200Ok, error) {
+	var orders []esi.GetMarketsRegionIdOrders200Ok
+	for page := int32(1); ; page++ {
+		oo, r, err := a.esiClient.ESI.MarketApi.GetMarketsRegionIdOrders(context.Background(), "all", regionID, &esi.GetMarketsRegionIdOrdersOpts{
+			Page:   optional.NewInt32(page),
+			TypeId: optional.NewInt32(typeID),
+		})
+		if err != nil {
+			if r != nil && r.StatusCode == http.StatusNotFound {
+				return orders, nil
+			}
+			return nil, err
+		}
+		orders = append(orders, oo...)
+		pages, _ := strconv.Atoi(r.Header.Get("X-Pages"))
+		if int(page) >= pages {
+			return orders, nil
+		}
+	}
+}
+
+// formatISK returns an ISK amount with thousands separators or an empty string when it is 0.
+func formatISK(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return formatNumber(v)
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_RunPrices(t *testing.T) {
+	entities := []entity{
+		{34, "Tritanium", "inventory_type"},
+		{35, "Pyerite", "inventory_type"},
+		{10000002, "The Forge", "region"},
+		{30000142, "Jita", "solar_system"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint(entities),
+	)
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/ids/`,
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"regions": []map[string]any{{"id": 10000002, "name": "The Forge"}},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/types/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			34: {"name": "Tritanium", "group_id": 18, "published": true},
+			35: {"name": "Pyerite", "group_id": 18, "published": true},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/groups/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			18: {"name": "Mineral", "category_id": 4, "published": true},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/categories/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			4: {"name": "Material", "published": true},
+		}),
+	)
+	pricesURL := `=~^https://esi\.evetech\.net/v\d+/markets/prices/`
+	httpmock.RegisterResponder(
+		"GET",
+		pricesURL,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"type_id": 34, "average_price": 3.95, "adjusted_price": 4.12},
+			{"type_id": 603, "average_price": 312000.5, "adjusted_price": 305432.17},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/markets/10000002/orders/`,
+		func(req *http.Request) (*http.Response, error) {
+			var data []map[string]any
+			switch req.URL.Query().Get("type_id") {
+			case "34":
+				switch req.URL.Query().Get("page") {
+				case "1":
+					data = []map[string]any{
+						{"is_buy_order": true, "location_id": 60003760, "price": 3.9},
+						{"is_buy_order": true, "location_id": 60003761, "price": 4.5},
+						{"is_buy_order": false, "location_id": 60003760, "price": 4.2},
+					}
+				case "2":
+					data = []map[string]any{
+						{"is_buy_order": false, "location_id": 60003760, "price": 4.05},
+						{"is_buy_order": false, "location_id": 60003761, "price": 3.5},
+					}
+				}
+			}
+			r, err := httpmock.NewJsonResponse(200, data)
+			if err != nil {
+				return nil, err
+			}
+			r.Header.Set("X-Pages", "2")
+			return r, nil
+		},
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show average and adjusted prices", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Prices = true
+		a.Columns = []string{"name", "averageprice", "adjustedprice"}
+		err := a.Run([]string{"34", "35"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Tritanium\s+│ 3.95\s+│ 4.12`, got)
+		assert.Regexp(t, `Pyerite\s+│\s+│\s+│`, got)
+		assert.NotContains(t, got, "BEST")
+	})
+	t.Run("should not show prices by default", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.Run([]string{"34"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.NotContains(t, buf.String(), "3.95")
+	})
+	t.Run("can show best prices at a trade hub", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Market = "Jita"
+		a.Columns = []string{"name", "averageprice", "bestbuy", "bestsell"}
+		err := a.Run([]string{"34"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Regexp(t, `Tritanium\s+│ 3.95\s+│ 3.90\s+│ 4.05`, buf.String())
+	})
+	t.Run("can show best prices in a region", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Market = "The Forge"
+		a.Columns = []string{"name", "bestbuy", "bestsell"}
+		err := a.Run([]string{"34"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Regexp(t, `Tritanium\s+│ 4.50\s+│ 3.50`, buf.String())
+	})
+	t.Run("should report invalid market", func(t *testing.T) {
+		st.MustClear()
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		a.Market = "30000142"
+		err := a.Run([]string{"34"})
+		assert.ErrorContains(t, err, "not a trade hub or region: 30000142")
+	})
+	t.Run("should use cached prices", func(t *testing.T) {
+		st.MustClear()
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		a.Prices = true
+		err := a.Run([]string{"34", "35"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		n := httpmock.GetCallCountInfo()["GET "+pricesURL]
+		err = a.Run([]string{"35"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Equal(t, n, httpmock.GetCallCountInfo()["GET "+pricesURL])
+	})
+}
+
+func TestFormatISK(t *testing.T) {
+	cases := []struct {
+		v    float64
+		want string
+	}{
+		{0, ""},
+		{4.1, "4.10"},
+		{1234567.891, "1,234,567.89"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, formatISK(tc.v))
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/antihax/goesi/esi"
+	"github.com/antihax/goesi/optional"
+)
+
+// Security preferences for routes
+const (
+	routeShortest = "shortest"
+	routeSecure   = "secure"
+	routeInsecure = "insecure"
+)
+
+var routePreferences = []string{routeShortest, routeSecure, routeInsecure}
+
+var securityBandColors = map[securityBand]string{
+	securityHigh: colorGreen,
+	securityLow:  colorYellow,
+	securityNull: colorRed,
+}
+
+// RunRoute shows the route between two solar systems with the security of each jump.
+func (a App) RunRoute(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("the route command needs an origin and a destination")
+	}
+	preference := a.RoutePreference
+	if preference == "" {
+		preference = routeShortest
+	}
+	if !slices.Contains(routePreferences, preference) {
+		return fmt.Errorf("valid route preferences are: %s", strings.Join(routePreferences, ", "))
+	}
+	bar := a.newSpinner("Fetching route ...")
+	origin, err := a.resolveSolarSystem(args[0])
+	if err != nil {
+		return err
+	}
+	destination, err := a.resolveSolarSystem(args[1])
+	if err != nil {
+		return err
+	}
+	var avoidIDs []int32
+	for _, v := range a.RouteAvoid {
+		e, err := a.resolveSolarSystem(v)
+		if err != nil {
+			return err
+		}
+		avoidIDs = append(avoidIDs, e.ID())
+	}
+	opts := &esi.GetRouteOriginDestinationOpts{Flag: optional.NewString(preference)}
+	if len(avoidIDs) > 0 {
+		opts.Avoid = optional.NewInterface(avoidIDs)
+	}
+	ids, r, err := a.esiClient.ESI.RoutesApi.GetRouteOriginDestination(context.Background(), destination.ID(), origin.ID(), opts)
+	if err != nil {
+		if r != nil && r.StatusCode == http.StatusNotFound {
+			if bar != nil {
+				bar.Clear()
+			}
+			fmt.Fprintf(a.out, "No route found from %s to %s\n", origin.Name, destination.Name)
+			return nil
+		}
+		return err
+	}
+	t, counts, err := a.buildRouteTable(ids)
+	if err != nil {
+		return err
+	}
+	if bar != nil {
+		bar.Clear()
+	}
+	title := fmt.Sprintf(
+		"Route from %s to %s (%d jumps: %d high sec, %d low sec, %d null sec)",
+		origin.Name,
+		destination.Name,
+		max(len(ids)-1, 0),
+		counts[securityHigh],
+		counts[securityLow],
+		counts[securityNull],
+	)
+	return a.printResults([]result{{title, t}})
+}
+
+// resolveSolarSystem returns the entity for a solar system given as ID or name.
+func (a App) resolveSolarSystem(value string) (EveEntity, error) {
+	ids, names, err := a.parseValues([]string{value})
+	if err != nil {
+		return EveEntity{}, err
+	}
+	entities, err := a.resolveValues(ids, names)
+	if err != nil {
+		return EveEntity{}, err
+	}
+	for _, e := range entities {
+		if e.Category == CategorySolarSystem {
+			return e, nil
+		}
+	}
+	return EveEntity{}, fmt.Errorf("not a solar system: %s", value)
+}
+
+// buildRouteTable returns a table with the solar systems of a route in order
+// and the number of jumps into each security band.
+func (a App) buildRouteTable(ids []int32) (*table, map[securityBand]int, error) {
+	locations, err := a.fetchLocations(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	counts := make(map[securityBand]int)
+	t := &table{headers: []string{"Jump", "SolarSystemID", "SolarSystemName", "Security", "Band", "RegionName"}}
+	for i, id := range ids {
+		l := locations[id]
+		band := l.solarSystem.SecurityBand()
+		if i > 0 {
+			counts[band]++
+		}
+		t.rows = append(t.rows, tableRow{
+			values: []any{i, id, l.solarSystem.Name, formatSecurity(l.solarSystem.DisplaySecurity()), string(band), l.region.Name},
+			color:  securityBandColors[band],
+		})
+	}
+	return t, counts, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_RunRoute(t *testing.T) {
+	entities := []entity{
+		{30000142, "Jita", "solar_system"},
+		{30000144, "Perimeter", "solar_system"},
+		{30002537, "Amamake", "solar_system"},
+		{30002813, "Tama", "solar_system"},
+		{93330670, "Erik Kalkoken", "character"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint(entities),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/route/30000142/30002537/`,
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if q.Get("flag") == "insecure" {
+				return httpmock.NewJsonResponse(404, map[string]any{"error": "No route found"})
+			}
+			if q.Get("avoid") == "30000144" {
+				return httpmock.NewJsonResponse(200, []int32{30000142, 30002813, 30002537})
+			}
+			return httpmock.NewJsonResponse(200, []int32{30000142, 30000144, 30002813, 30002537})
+		},
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/systems/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			30000142: {"constellation_id": 20000020, "name": "Jita", "security_status": 0.945913},
+			30000144: {"constellation_id": 20000020, "name": "Perimeter", "security_status": 0.954252},
+			30002813: {"constellation_id": 20000020, "name": "Tama", "security_status": 0.3},
+			30002537: {"constellation_id": 20000020, "name": "Amamake", "security_status": -0.04},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/constellations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			20000020: {"name": "Kimotoro", "region_id": 10000002},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/regions/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			10000002: {"name": "The Forge"},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show route with security of each jump", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunRoute([]string{"30000142", "30002537"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Route from Jita to Amamake (3 jumps: 1 high sec, 1 low sec, 1 null sec):")
+		assert.Contains(t, got, colorize("Perimeter", colorGreen))
+		assert.Contains(t, got, colorize("Tama", colorYellow))
+		assert.Contains(t, got, colorize("Amamake", colorRed))
+		assert.Contains(t, got, colorize("0.0", colorRed))
+	})
+	t.Run("can avoid solar systems", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.RouteAvoid = []string{"30000144"}
+		err := a.RunRoute([]string{"30000142", "30002537"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "(2 jumps: 0 high sec, 1 low sec, 1 null sec)")
+		assert.NotContains(t, got, "Perimeter")
+	})
+	t.Run("should report when no route is found", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.RoutePreference = routeInsecure
+		err := a.RunRoute([]string{"30000142", "30002537"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Contains(t, buf.String(), "No route found from Jita to Amamake")
+	})
+	t.Run("should report error for invalid preference", func(t *testing.T) {
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		a.RoutePreference = "fastest"
+		err := a.RunRoute([]string{"30000142", "30002537"})
+		assert.ErrorContains(t, err, "valid route preferences")
+	})
+	t.Run("should report error when endpoint is not a solar system", func(t *testing.T) {
+		st.MustClear()
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		err := a.RunRoute([]string{"30000142", "93330670"})
+		assert.ErrorContains(t, err, "not a solar system: 93330670")
+	})
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// RunSov shows the solar systems held by alliances.
+func (a App) RunSov(args []string) error {
+	ids, names, err := a.parseValues(args)
+	if err != nil {
+		return err
+	}
+	standings, err := a.resolveWatchlist()
+	if err != nil {
+		return err
+	}
+	a.standings = standings
+	bar := a.newSpinner(fmt.Sprintf("Fetching sovereignty for %d IDs/names ...", len(ids)+len(names)))
+	entities, err := a.resolveValues(ids, names)
+	if err != nil {
+		return err
+	}
+	sovereignty, err := a.fetchSovereigntyMap()
+	if err != nil {
+		return err
+	}
+	var results []result
+	var unsupported []EveEntity
+	for _, e := range entities {
+		if e.Category != CategoryAlliance {
+			unsupported = append(unsupported, e)
+			continue
+		}
+		var held []EveSovereignty
+		for _, o := range sovereignty {
+			if o.AllianceID == e.ID() {
+				held = append(held, o)
+			}
+		}
+		t, err := a.buildSovTable(held)
+		if err != nil {
+			return err
+		}
+		title := fmt.Sprintf("Sovereignty of %s (%d systems)", e.Name, len(t.rows))
+		results = append(results, result{title, t})
+	}
+	if bar != nil {
+		bar.Clear()
+	}
+	for _, e := range unsupported {
+		fmt.Fprintf(a.out, "Not an alliance: %s\n", entityDisplayName(e))
+	}
+	return a.printResults(results)
+}
+
+// buildSovTable returns a table with the given solar systems and their sovereignty structures
+// sorted by region and solar system name.
+func (a App) buildSovTable(sovereignty []EveSovereignty) (*table, error) {
+	var systemIDs, typeIDs []int32
+	for _, o := range sovereignty {
+		systemIDs = append(systemIDs, o.SolarSystemID)
+		for _, s := range o.Structures {
+			typeIDs = append(typeIDs, s.TypeID)
+		}
+	}
+	locations, err := a.fetchLocations(systemIDs)
+	if err != nil {
+		return nil, err
+	}
+	types, err := a.fetchTypes(typeIDs)
+	if err != nil {
+		return nil, err
+	}
+	typeLookup := makeLookupMap(types)
+	slices.SortFunc(sovereignty, func(x, y EveSovereignty) int {
+		lx, ly := locations[x.SolarSystemID], locations[y.SolarSystemID]
+		return cmp.Or(cmp.Compare(lx.region.Name, ly.region.Name), cmp.Compare(lx.solarSystem.Name, ly.solarSystem.Name))
+	})
+	t := &table{headers: []string{"SolarSystemID", "SolarSystemName", "Security", "ConstellationName", "RegionName", "ADM", "Structures"}}
+	for _, o := range sovereignty {
+		l := locations[o.SolarSystemID]
+		var structures []string
+		for _, s := range o.Structures {
+			structures = append(structures, typeLookup[s.TypeID].Name)
+		}
+		slices.Sort(structures)
+		t.rows = append(t.rows, tableRow{
+			values: []any{
+				o.SolarSystemID,
+				l.solarSystem.Name,
+				formatSecurity(l.solarSystem.DisplaySecurity()),
+				l.constellation.Name,
+				l.region.Name,
+				formatADM(o),
+				strings.Join(structures, ", "),
+			},
+			color: a.highlightColor(o.AllianceID),
+		})
+	}
+	return t, nil
+}
+
+// fetchSovereignty returns the sovereignty of solar systems by their ID.
+func (a App) fetchSovereignty(solarSystemIDs []int32) (map[int32]EveSovereignty, error) {
+	objs, missing, err := a.st.ListFreshEveSovereigntyByID(solarSystemIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		refreshed, err := a.refreshSovereignty(missing)
+		if err != nil {
+			return nil, err
+		}
+		objs = slices.DeleteFunc(refreshed, func(o EveSovereignty) bool {
+			return !slices.Contains(solarSystemIDs, o.SolarSystemID)
+		})
+	}
+	return makeLookupMap(objs), nil
+}
+
+// fetchSovereigntyMap returns the sovereignty of all solar systems.
+func (a App) fetchSovereigntyMap() ([]EveSovereignty, error) {
+	objs, err := a.st.ListEveSovereignty()
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) > 0 && !slices.ContainsFunc(objs, func(o EveSovereignty) bool {
+		return o.IsStale()
+	}) {
+		return objs, nil
+	}
+	return a.refreshSovereignty(nil)
+}
+
+// refreshSovereignty fetches the sovereignty map and structures from ESI, stores them and returns them.
+// Additional solar systems which are not on the map are stored without a holder.
+func (a App) refreshSovereignty(solarSystemIDs []int32) ([]EveSovereignty, error) {
+	g := new(errgroup.Group)
+	sovereignty := make(map[int32]EveSovereignty)
+	g.Go(func() error {
+		data, _, err := a.esiClient.ESI.SovereigntyApi.GetSovereigntyMap(context.Background(), nil)
+		if err != nil {
+			return err
+		}
+		for _, x := range data {
+			sovereignty[x.SystemId] = EveSovereignty{
+				AllianceID:    x.AllianceId,
+				CorporationID: x.CorporationId,
+				FactionID:     x.FactionId,
+				SolarSystemID: x.SystemId,
+				Timestamp:     now(),
+			}
+		}
+		return nil
+	})
+	structures := make(map[int32][]EveSovereigntyStructure)
+	g.Go(func() error {
+		data, _, err := a.esiClient.ESI.SovereigntyApi.GetSovereigntyStructures(context.Background(), nil)
+		if err != nil {
+			return err
+		}
+		for _, x := range data {
+			structures[x.SolarSystemId] = append(structures[x.SolarSystemId], EveSovereigntyStructure{
+				AllianceID:                  x.AllianceId,
+				StructureID:                 x.StructureId,
+				TypeID:                      x.StructureTypeId,
+				VulnerabilityOccupancyLevel: x.VulnerabilityOccupancyLevel,
+				VulnerableEndTime:           x.VulnerableEndTime,
+				VulnerableStartTime:         x.VulnerableStartTime,
+			})
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	for _, id := range solarSystemIDs {
+		if _, ok := sovereignty[id]; !ok {
+			sovereignty[id] = EveSovereignty{SolarSystemID: id, Timestamp: now()}
+		}
+	}
+	objs := make([]EveSovereignty, 0, len(sovereignty))
+	for id, o := range sovereignty {
+		o.Structures = structures[id]
+		objs = append(objs, o)
+	}
+	if err := a.st.UpdateOrCreateEveSovereignty(objs); err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+// fetchSovereigntyHolders returns the name of the sovereignty holder for each solar system by their ID.
+// The holder is an alliance, a corporation or a faction.
+func (a App) fetchSovereigntyHolders(sovereignty map[int32]EveSovereignty) (map[int32]string, error) {
+	var allianceIDs, corporationIDs, factionIDs []int32
+	for _, o := range sovereignty {
+		switch {
+		case o.AllianceID != 0:
+			allianceIDs = append(allianceIDs, o.AllianceID)
+		case o.CorporationID != 0:
+			corporationIDs = append(corporationIDs, o.CorporationID)
+		case o.FactionID != 0:
+			factionIDs = append(factionIDs, o.FactionID)
+		}
+	}
+	alliances, err := a.fetchAlliance(allianceIDs)
+	if err != nil {
+		return nil, err
+	}
+	corporations, err := a.fetchCorporations(corporationIDs)
+	if err != nil {
+		return nil, err
+	}
+	factions, err := a.fetchFactions(factionIDs)
+	if err != nil {
+		return nil, err
+	}
+	allianceLookup := makeLookupMap(alliances)
+	corporationLookup := makeLookupMap(corporations)
+	factionLookup := makeLookupMap(factions)
+	holders := make(map[int32]string)
+	for id, o := range sovereignty {
+		switch {
+		case o.AllianceID != 0:
+			holders[id] = allianceLookup[o.AllianceID].Name
+		case o.CorporationID != 0:
+			holders[id] = corporationLookup[o.CorporationID].Name
+		case o.FactionID != 0:
+			holders[id] = factionLookup[o.FactionID].Name
+		}
+	}
+	return holders, nil
+}
+
+// formatADM returns the activity defense multiplier of a solar system or an empty string when it has no structures.
+func formatADM(o EveSovereignty) string {
+	if len(o.Structures) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.1f", o.ADM())
+}
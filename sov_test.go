@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_RunSov(t *testing.T) {
+	entities := []entity{
+		{30000001, "Alpha", "solar_system"},
+		{30000002, "Bravo", "solar_system"},
+		{30000003, "Charlie", "solar_system"},
+		{30000004, "Delta", "solar_system"},
+		{99000001, "Test Alliance", "alliance"},
+		{98000001, "Test Corporation", "corporation"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint(entities),
+	)
+	mapURL := `=~^https://esi\.evetech\.net/v\d+/sovereignty/map/`
+	httpmock.RegisterResponder(
+		"GET",
+		mapURL,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"system_id": 30000001, "alliance_id": 99000001, "corporation_id": 98000001},
+			{"system_id": 30000002, "alliance_id": 99000001, "corporation_id": 98000001},
+			{"system_id": 30000003, "faction_id": 500001},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/sovereignty/structures/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"alliance_id": 99000001, "solar_system_id": 30000001, "structure_id": 1001, "structure_type_id": 32226, "vulnerability_occupancy_level": 4.2},
+			{"alliance_id": 99000001, "solar_system_id": 30000001, "structure_id": 1002, "structure_type_id": 32458, "vulnerability_occupancy_level": 5.1},
+			{"alliance_id": 99000001, "solar_system_id": 30000002, "structure_id": 1003, "structure_type_id": 32226, "vulnerability_occupancy_level": 1.5},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/systems/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			30000001: {"constellation_id": 20000001, "name": "Alpha", "security_status": -0.3},
+			30000002: {"constellation_id": 20000001, "name": "Bravo", "security_status": -0.5},
+			30000003: {"constellation_id": 20000001, "name": "Charlie", "security_status": 0.9},
+			30000004: {"constellation_id": 20000001, "name": "Delta", "security_status": -1.0},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/constellations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			20000001: {"name": "Constellation", "region_id": 10000001},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/regions/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			10000001: {"name": "Region"},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/types/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			32226: {"name": "Territorial Claim Unit", "group_id": 1003, "published": true},
+			32458: {"name": "Infrastructure Hub", "group_id": 1012, "published": true},
+		}),
+	)
+	alliancesURL := `=~^https://esi\.evetech\.net/v\d+/alliances/(\d+)/`
+	httpmock.RegisterResponder(
+		"GET",
+		alliancesURL,
+		makeObjectResponder(map[int64]map[string]any{
+			99000001: {"name": "Test Alliance", "ticker": "TEST", "executor_corporation_id": 98000001},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/universe/factions/`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{"faction_id": 500001, "name": "Caldari State", "description": "dummy"},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show solar systems held by an alliance", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunSov([]string{"99000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Sovereignty of Test Alliance (2 systems):")
+		assert.Regexp(t, `(?s)30000001\s+│ Alpha\s+│ -0.3\s+│ Constellation\s+│ Region\s+│ 5.1\s+│ Infrastructure Hub, Territorial Claim Unit.+30000002\s+│ Bravo.+│ 1.5\s+│ Territorial Claim Unit`, got)
+		assert.NotContains(t, got, "Charlie")
+	})
+	t.Run("should report values which are not alliances", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.RunSov([]string{"98000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Contains(t, buf.String(), "Not an alliance: Test Corporation")
+	})
+	t.Run("can show sovereignty holders in solar system table", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Columns = []string{"name", "sovereignty"}
+		err := a.Run([]string{"30000001", "30000003", "30000004"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Alpha\s+│ Test Alliance`, got)
+		assert.Regexp(t, `Charlie\s+│ Caldari State`, got)
+		assert.Regexp(t, `Delta\s+│\s+│`, got)
+	})
+	t.Run("should only fetch holders of requested solar systems", func(t *testing.T) {
+		st.MustClear()
+		httpmock.ZeroCallCounters()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Columns = []string{"name", "sovereignty"}
+		err := a.Run([]string{"30000004"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Regexp(t, `Delta\s+│\s+│`, buf.String())
+		assert.Equal(t, 0, httpmock.GetCallCountInfo()["GET "+alliancesURL])
+	})
+	t.Run("should use cached sovereignty", func(t *testing.T) {
+		st.MustClear()
+		a := NewApp(esiClient, st, &bytes.Buffer{})
+		a.SpinnerDisabled = true
+		err := a.Run([]string{"30000004"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		n := httpmock.GetCallCountInfo()["GET "+mapURL]
+		err = a.Run([]string{"30000001", "30000004"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		err = a.RunSov([]string{"99000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Equal(t, n, httpmock.GetCallCountInfo()["GET "+mapURL])
+	})
+}
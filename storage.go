@@ -9,34 +9,58 @@ import (
 	bolt "go.etcd.io/bbolt"
 )
 
-//go:generate go run ./tools/genstorage EveAlliance EveCategory EveCharacter EveConstellation EveCorporation EveEntity EveFaction EveGroup EveRegion EveSolarSystem EveStation EveType
+//go:generate go run ./tools/genstorage EveAlliance EveAsteroidBelt EveBloodline EveCategory EveCharacter EveConstellation EveCorporation EveDogmaAttribute EveEntity EveFaction EveGroup EveMarketGroup EveMarketPrice EveMoon EvePlanet EveRace EveRegion EveSolarSystem EveSolarSystemActivity EveSovereignty EveStar EveStargate EveStation EveType
 
 const (
-	bucketEveAlliance      = "eve_alliances"
-	bucketEveCategory      = "eve_categories"
-	bucketEveCharacter     = "eve_characters"
-	bucketEveConstellation = "eve_constellations"
-	bucketEveCorporation   = "eve_corporations"
-	bucketEveEntity        = "eve_entities"
-	bucketEveFaction       = "eve_factions"
-	bucketEveGroup         = "eve_groups"
-	bucketEveRegion        = "eve_regions"
-	bucketEveSolarSystem   = "eve_solar_systems"
-	bucketEveStation       = "eve_stations"
-	bucketEveType          = "eve_types"
+	bucketEveAlliance            = "eve_alliances"
+	bucketEveAsteroidBelt        = "eve_asteroid_belts"
+	bucketEveBloodline           = "eve_bloodlines"
+	bucketEveCategory            = "eve_categories"
+	bucketEveCharacter           = "eve_characters"
+	bucketEveConstellation       = "eve_constellations"
+	bucketEveCorporation         = "eve_corporations"
+	bucketEveDogmaAttribute      = "eve_dogma_attributes"
+	bucketEveEntity              = "eve_entities"
+	bucketEveFaction             = "eve_factions"
+	bucketEveGroup               = "eve_groups"
+	bucketEveMarketGroup         = "eve_market_groups"
+	bucketEveMarketPrice         = "eve_market_prices"
+	bucketEveMoon                = "eve_moons"
+	bucketEvePlanet              = "eve_planets"
+	bucketEveRace                = "eve_races"
+	bucketEveRegion              = "eve_regions"
+	bucketEveSolarSystem         = "eve_solar_systems"
+	bucketEveSolarSystemActivity = "eve_solar_system_activity"
+	bucketEveSovereignty         = "eve_sovereignty"
+	bucketEveStar                = "eve_stars"
+	bucketEveStargate            = "eve_stargates"
+	bucketEveStation             = "eve_stations"
+	bucketEveType                = "eve_types"
 )
 
 var bucketNames = []string{
 	bucketEveAlliance,
+	bucketEveAsteroidBelt,
+	bucketEveBloodline,
 	bucketEveCategory,
 	bucketEveCharacter,
 	bucketEveConstellation,
 	bucketEveCorporation,
+	bucketEveDogmaAttribute,
 	bucketEveEntity,
 	bucketEveFaction,
 	bucketEveGroup,
+	bucketEveMarketGroup,
+	bucketEveMarketPrice,
+	bucketEveMoon,
+	bucketEvePlanet,
+	bucketEveRace,
 	bucketEveRegion,
 	bucketEveSolarSystem,
+	bucketEveSolarSystemActivity,
+	bucketEveSovereignty,
+	bucketEveStar,
+	bucketEveStargate,
 	bucketEveStation,
 	bucketEveType,
 }
@@ -158,7 +182,9 @@ func listEveObjects[T EveObject](st *Storage, bucket string) ([]T, error) {
 	return objs, nil
 }
 
-func listFreshEveObjectsByID[T EveObject](st *Storage, bucket string, ids []int32) ([]T, []int32, error) {
+// listEveObjectsByID returns the objects with the given IDs incl. stale objects
+// and the IDs of objects which were not found.
+func listEveObjectsByID[T EveObject](st *Storage, bucket string, ids []int32) ([]T, []int32, error) {
 	notFound := make([]int32, 0)
 	objs := make([]T, 0)
 	if err := st.db.View(func(tx *bolt.Tx) error {
@@ -177,15 +203,29 @@ func listFreshEveObjectsByID[T EveObject](st *Storage, bucket string, ids []int3
 			if err := json.Unmarshal(v, &o); err != nil {
 				return err
 			}
-			if o.IsStale() {
-				notFound = append(notFound, id)
-				continue
-			}
 			objs = append(objs, o)
 		}
 		return nil
 	}); err != nil {
-		return nil, nil, fmt.Errorf("listFreshEveObjectsByID: %T: %w", objs, err)
+		return nil, nil, fmt.Errorf("listEveObjectsByID: %T: %w", objs, err)
+	}
+	return objs, notFound, nil
+}
+
+// listFreshEveObjectsByID returns the fresh objects with the given IDs
+// and the IDs of objects which were not found or are stale.
+func listFreshEveObjectsByID[T EveObject](st *Storage, bucket string, ids []int32) ([]T, []int32, error) {
+	oo, notFound, err := listEveObjectsByID[T](st, bucket, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	objs := make([]T, 0, len(oo))
+	for _, o := range oo {
+		if o.IsStale() {
+			notFound = append(notFound, o.ID())
+			continue
+		}
+		objs = append(objs, o)
 	}
 	return objs, notFound, nil
 }
@@ -7,6 +7,10 @@ func (st *Storage) ListEveAlliance() ([]EveAlliance, error) {
     return listEveObjects[EveAlliance](st, bucketEveAlliance)
 }
 
+func (st *Storage) ListEveAllianceByID(ids []int32) ([]EveAlliance, []int32, error) {
+    return listEveObjectsByID[EveAlliance](st, bucketEveAlliance, ids)
+}
+
 func (st *Storage) ListFreshEveAllianceByID(ids []int32) ([]EveAlliance, []int32, error) {
     return listFreshEveObjectsByID[EveAlliance](st, bucketEveAlliance, ids)
 }
@@ -16,10 +20,48 @@ func (st *Storage) UpdateOrCreateEveAlliance(objs []EveAlliance) error {
 }
 
 
+func (st *Storage) ListEveAsteroidBelt() ([]EveAsteroidBelt, error) {
+    return listEveObjects[EveAsteroidBelt](st, bucketEveAsteroidBelt)
+}
+
+func (st *Storage) ListEveAsteroidBeltByID(ids []int32) ([]EveAsteroidBelt, []int32, error) {
+    return listEveObjectsByID[EveAsteroidBelt](st, bucketEveAsteroidBelt, ids)
+}
+
+func (st *Storage) ListFreshEveAsteroidBeltByID(ids []int32) ([]EveAsteroidBelt, []int32, error) {
+    return listFreshEveObjectsByID[EveAsteroidBelt](st, bucketEveAsteroidBelt, ids)
+}
+
+func (st *Storage) UpdateOrCreateEveAsteroidBelt(objs []EveAsteroidBelt) error {
+    return updateOrCreateEveObjects(st, bucketEveAsteroidBelt, objs)
+}
+
+
+func (st *Storage) ListEveBloodline() ([]EveBloodline, error) {
+    return listEveObjects[EveBloodline](st, bucketEveBloodline)
+}
+
+func (st *Storage) ListEveBloodlineByID(ids []int32) ([]EveBloodline, []int32, error) {
+    return listEveObjectsByID[EveBloodline](st, bucketEveBloodline, ids)
+}
+
+func (st *Storage) ListFreshEveBloodlineByID(ids []int32) ([]EveBloodline, []int32, error) {
+    return listFreshEveObjectsByID[EveBloodline](st, bucketEveBloodline, ids)
+}
+
+func (st *Storage) UpdateOrCreateEveBloodline(objs []EveBloodline) error {
+    return updateOrCreateEveObjects(st, bucketEveBloodline, objs)
+}
+
+
 func (st *Storage) ListEveCategory() ([]EveCategory, error) {
     return listEveObjects[EveCategory](st, bucketEveCategory)
 }
 
+func (st *Storage) ListEveCategoryByID(ids []int32) ([]EveCategory, []int32, error) {
+    return listEveObjectsByID[EveCategory](st, bucketEveCategory, ids)
+}
+
 func (st *Storage) ListFreshEveCategoryByID(ids []int32) ([]EveCategory, []int32, error) {
     return listFreshEveObjectsByID[EveCategory](st, bucketEveCategory, ids)
 }
@@ -33,6 +75,10 @@ func (st *Storage) ListEveCharacter() ([]EveCharacter, error) {
     return listEveObjects[EveCharacter](st, bucketEveCharacter)
 }
 
+func (st *Storage) ListEveCharacterByID(ids []int32) ([]EveCharacter, []int32, error) {
+    return listEveObjectsByID[EveCharacter](st, bucketEveCharacter, ids)
+}
+
 func (st *Storage) ListFreshEveCharacterByID(ids []int32) ([]EveCharacter, []int32, error) {
     return listFreshEveObjectsByID[EveCharacter](st, bucketEveCharacter, ids)
 }
@@ -46,6 +92,10 @@ func (st *Storage) ListEveConstellation() ([]EveConstellation, error) {
     return listEveObjects[EveConstellation](st, bucketEveConstellation)
 }
 
+func (st *Storage) ListEveConstellationByID(ids []int32) ([]EveConstellation, []int32, error) {
+    return listEveObjectsByID[EveConstellation](st, bucketEveConstellation, ids)
+}
+
 func (st *Storage) ListFreshEveConstellationByID(ids []int32) ([]EveConstellation, []int32, error) {
     return listFreshEveObjectsByID[EveConstellation](st, bucketEveConstellation, ids)
 }
@@ -59,6 +109,10 @@ func (st *Storage) ListEveCorporation() ([]EveCorporation, error) {
     return listEveObjects[EveCorporation](st, bucketEveCorporation)
 }
 
+func (st *Storage) ListEveCorporationByID(ids []int32) ([]EveCorporation, []int32, error) {
+    return listEveObjectsByID[EveCorporation](st, bucketEveCorporation, ids)
+}
+
 func (st *Storage) ListFreshEveCorporationByID(ids []int32) ([]EveCorporation, []int32, error) {
     return listFreshEveObjectsByID[EveCorporation](st, bucketEveCorporation, ids)
 }
@@ -68,10 +122,31 @@ func (st *Storage) UpdateOrCreateEveCorporation(objs []EveCorporation) error {
 }
 
 
+func (st *Storage) ListEveDogmaAttribute() ([]EveDogmaAttribute, error) {
+    return listEveObjects[EveDogmaAttribute](st, bucketEveDogmaAttribute)
+}
+
+func (st *Storage) ListEveDogmaAttributeByID(ids []int32) ([]EveDogmaAttribute, []int32, error) {
+    return listEveObjectsByID[EveDogmaAttribute](st, bucketEveDogmaAttribute, ids)
+}
+
+func (st *Storage) ListFreshEveDogmaAttributeByID(ids []int32) ([]EveDogmaAttribute, []int32, error) {
+    return listFreshEveObjectsByID[EveDogmaAttribute](st, bucketEveDogmaAttribute, ids)
+}
+
+func (st *Storage) UpdateOrCreateEveDogmaAttribute(objs []EveDogmaAttribute) error {
+    return updateOrCreateEveObjects(st, bucketEveDogmaAttribute, objs)
+}
+
+
 func (st *Storage) ListEveEntity() ([]EveEntity, error) {
     return listEveObjects[EveEntity](st, bucketEveEntity)
 }
 
+func (st *Storage) ListEveEntityByID(ids []int32) ([]EveEntity, []int32, error) {
+    return listEveObjectsByID[EveEntity](st, bucketEveEntity, ids)
+}
+
 func (st *Storage) ListFreshEveEntityByID(ids []int32) ([]EveEntity, []int32, error) {
     return listFreshEveObjectsByID[EveEntity](st, bucketEveEntity, ids)
 }
@@ -85,6 +160,10 @@ func (st *Storage) ListEveFaction() ([]EveFaction, error) {
     return listEveObjects[EveFaction](st, bucketEveFaction)
 }
 
+func (st *Storage) ListEveFactionByID(ids []int32) ([]EveFaction, []int32, error) {
+    return listEveObjectsByID[EveFaction](st, bucketEveFaction, ids)
+}
+
 func (st *Storage) ListFreshEveFactionByID(ids []int32) ([]EveFaction, []int32, error) {
     return listFreshEveObjectsByID[EveFaction](st, bucketEveFaction, ids)
 }
@@ -98,6 +177,10 @@ func (st *Storage) ListEveGroup() ([]EveGroup, error) {
     return listEveObjects[EveGroup](st, bucketEveGroup)
 }
 
+func (st *Storage) ListEveGroupByID(ids []int32) ([]EveGroup, []int32, error) {
+    return listEveObjectsByID[EveGroup](st, bucketEveGroup, ids)
+}
+
 func (st *Storage) ListFreshEveGroupByID(ids []int32) ([]EveGroup, []int32, error) {
     return listFreshEveObjectsByID[EveGroup](st, bucketEveGroup, ids)
 }
@@ -107,10 +190,99 @@ func (st *Storage) UpdateOrCreateEveGroup(objs []EveGroup) error {
 }
 
 
+func (st *Storage) ListEveMarketGroup() ([]EveMarketGroup, error) {
+    return listEveObjects[EveMarketGroup](st, bucketEveMarketGroup)
+}
+
+func (st *Storage) ListEveMarketGroupByID(ids []int32) ([]EveMarketGroup, []int32, error) {
+    return listEveObjectsByID[EveMarketGroup](st, bucketEveMarketGroup, ids)
+}
+
+func (st *Storage) ListFreshEveMarketGroupByID(ids []int32) ([]EveMarketGroup, []int32, error) {
+    return listFreshEveObjectsByID[EveMarketGroup](st, bucketEveMarketGroup, ids)
+}
+
+func (st *Storage) UpdateOrCreateEveMarketGroup(objs []EveMarketGroup) error {
+    return updateOrCreateEveObjects(st, bucketEveMarketGroup, objs)
+}
+
+
+func (st *Storage) ListEveMarketPrice() ([]EveMarketPrice, error) {
+    return listEveObjects[EveMarketPrice](st, bucketEveMarketPrice)
+}
+
+func (st *Storage) ListEveMarketPriceByID(ids []int32) ([]EveMarketPrice, []int32, error) {
+    return listEveObjectsByID[EveMarketPrice](st, bucketEveMarketPrice, ids)
+}
+
+func (st *Storage) ListFreshEveMarketPriceByID(ids []int32) ([]EveMarketPrice, []int32, error) {
+    return listFreshEveObjectsByID[EveMarketPrice](st, bucketEveMarketPrice, ids)
+}
+
+func (st *Storage) UpdateOrCreateEveMarketPrice(objs []EveMarketPrice) error {
+    return updateOrCreateEveObjects(st, bucketEveMarketPrice, objs)
+}
+
+
+func (st *Storage) ListEveMoon() ([]EveMoon, error) {
+    return listEveObjects[EveMoon](st, bucketEveMoon)
+}
+
+func (st *Storage) ListEveMoonByID(ids []int32) ([]EveMoon, []int32, error) {
+    return listEveObjectsByID[EveMoon](st, bucketEveMoon, ids)
+}
+
+func (st *Storage) ListFreshEveMoonByID(ids []int32) ([]EveMoon, []int32, error) {
+    return listFreshEveObjectsByID[EveMoon](st, bucketEveMoon, ids)
+}
+
+func (st *Storage) UpdateOrCreateEveMoon(objs []EveMoon) error {
+    return updateOrCreateEveObjects(st, bucketEveMoon, objs)
+}
+
+
+func (st *Storage) ListEvePlanet() ([]EvePlanet, error) {
+    return listEveObjects[EvePlanet](st, bucketEvePlanet)
+}
+
+func (st *Storage) ListEvePlanetByID(ids []int32) ([]EvePlanet, []int32, error) {
+    return listEveObjectsByID[EvePlanet](st, bucketEvePlanet, ids)
+}
+
+func (st *Storage) ListFreshEvePlanetByID(ids []int32) ([]EvePlanet, []int32, error) {
+    return listFreshEveObjectsByID[EvePlanet](st, bucketEvePlanet, ids)
+}
+
+func (st *Storage) UpdateOrCreateEvePlanet(objs []EvePlanet) error {
+    return updateOrCreateEveObjects(st, bucketEvePlanet, objs)
+}
+
+
+func (st *Storage) ListEveRace() ([]EveRace, error) {
+    return listEveObjects[EveRace](st, bucketEveRace)
+}
+
+func (st *Storage) ListEveRaceByID(ids []int32) ([]EveRace, []int32, error) {
+    return listEveObjectsByID[EveRace](st, bucketEveRace, ids)
+}
+
+func (st *Storage) ListFreshEveRaceByID(ids []int32) ([]EveRace, []int32, error) {
+    return listFreshEveObjectsByID[EveRace](st, bucketEveRace, ids)
+}
+
+func (st *Storage) UpdateOrCreateEveRace(objs []EveRace) error {
+    return updateOrCreateEveObjects(st, bucketEveRace, objs)
+}
+
+
 func (st *Storage) ListEveRegion() ([]EveRegion, error) {
     return listEveObjects[EveRegion](st, bucketEveRegion)
 }
 
+func (st *Storage) ListEveRegionByID(ids []int32) ([]EveRegion, []int32, error) {
+    return listEveObjectsByID[EveRegion](st, bucketEveRegion, ids)
+}
+
 func (st *Storage) ListFreshEveRegionByID(ids []int32) ([]EveRegion, []int32, error) {
     return listFreshEveObjectsByID[EveRegion](st, bucketEveRegion, ids)
 }
@@ -124,6 +296,10 @@ func (st *Storage) ListEveSolarSystem() ([]EveSolarSystem, error) {
     return listEveObjects[EveSolarSystem](st, bucketEveSolarSystem)
 }
 
+func (st *Storage) ListEveSolarSystemByID(ids []int32) ([]EveSolarSystem, []int32, error) {
+    return listEveObjectsByID[EveSolarSystem](st, bucketEveSolarSystem, ids)
+}
+
 func (st *Storage) ListFreshEveSolarSystemByID(ids []int32) ([]EveSolarSystem, []int32, error) {
     return listFreshEveObjectsByID[EveSolarSystem](st, bucketEveSolarSystem, ids)
 }
@@ -133,10 +309,82 @@ func (st *Storage) UpdateOrCreateEveSolarSystem(objs []EveSolarSystem) error {
 }
 
 
+func (st *Storage) ListEveSolarSystemActivity() ([]EveSolarSystemActivity, error) {
+    return listEveObjects[EveSolarSystemActivity](st, bucketEveSolarSystemActivity)
+}
+
+func (st *Storage) ListEveSolarSystemActivityByID(ids []int32) ([]EveSolarSystemActivity, []int32, error) {
+    return listEveObjectsByID[EveSolarSystemActivity](st, bucketEveSolarSystemActivity, ids)
+}
+
+func (st *Storage) ListFreshEveSolarSystemActivityByID(ids []int32) ([]EveSolarSystemActivity, []int32, error) {
+    return listFreshEveObjectsByID[EveSolarSystemActivity](st, bucketEveSolarSystemActivity, ids)
+}
+
+func (st *Storage) UpdateOrCreateEveSolarSystemActivity(objs []EveSolarSystemActivity) error {
+    return updateOrCreateEveObjects(st, bucketEveSolarSystemActivity, objs)
+}
+
+
+func (st *Storage) ListEveSovereignty() ([]EveSovereignty, error) {
+    return listEveObjects[EveSovereignty](st, bucketEveSovereignty)
+}
+
+func (st *Storage) ListEveSovereigntyByID(ids []int32) ([]EveSovereignty, []int32, error) {
+    return listEveObjectsByID[EveSovereignty](st, bucketEveSovereignty, ids)
+}
+
+func (st *Storage) ListFreshEveSovereigntyByID(ids []int32) ([]EveSovereignty, []int32, error) {
+    return listFreshEveObjectsByID[EveSovereignty](st, bucketEveSovereignty, ids)
+}
+
+func (st *Storage) UpdateOrCreateEveSovereignty(objs []EveSovereignty) error {
+    return updateOrCreateEveObjects(st, bucketEveSovereignty, objs)
+}
+
+
+func (st *Storage) ListEveStar() ([]EveStar, error) {
+    return listEveObjects[EveStar](st, bucketEveStar)
+}
+
+func (st *Storage) ListEveStarByID(ids []int32) ([]EveStar, []int32, error) {
+    return listEveObjectsByID[EveStar](st, bucketEveStar, ids)
+}
+
+func (st *Storage) ListFreshEveStarByID(ids []int32) ([]EveStar, []int32, error) {
+    return listFreshEveObjectsByID[EveStar](st, bucketEveStar, ids)
+}
+
+func (st *Storage) UpdateOrCreateEveStar(objs []EveStar) error {
+    return updateOrCreateEveObjects(st, bucketEveStar, objs)
+}
+
+
+func (st *Storage) ListEveStargate() ([]EveStargate, error) {
+    return listEveObjects[EveStargate](st, bucketEveStargate)
+}
+
+func (st *Storage) ListEveStargateByID(ids []int32) ([]EveStargate, []int32, error) {
+    return listEveObjectsByID[EveStargate](st, bucketEveStargate, ids)
+}
+
+func (st *Storage) ListFreshEveStargateByID(ids []int32) ([]EveStargate, []int32, error) {
+    return listFreshEveObjectsByID[EveStargate](st, bucketEveStargate, ids)
+}
+
+func (st *Storage) UpdateOrCreateEveStargate(objs []EveStargate) error {
+    return updateOrCreateEveObjects(st, bucketEveStargate, objs)
+}
+
+
 func (st *Storage) ListEveStation() ([]EveStation, error) {
     return listEveObjects[EveStation](st, bucketEveStation)
 }
 
+func (st *Storage) ListEveStationByID(ids []int32) ([]EveStation, []int32, error) {
+    return listEveObjectsByID[EveStation](st, bucketEveStation, ids)
+}
+
 func (st *Storage) ListFreshEveStationByID(ids []int32) ([]EveStation, []int32, error) {
     return listFreshEveObjectsByID[EveStation](st, bucketEveStation, ids)
 }
@@ -150,6 +398,10 @@ func (st *Storage) ListEveType() ([]EveType, error) {
     return listEveObjects[EveType](st, bucketEveType)
 }
 
+func (st *Storage) ListEveTypeByID(ids []int32) ([]EveType, []int32, error) {
+    return listEveObjectsByID[EveType](st, bucketEveType, ids)
+}
+
 func (st *Storage) ListFreshEveTypeByID(ids []int32) ([]EveType, []int32, error) {
     return listFreshEveObjectsByID[EveType](st, bucketEveType, ids)
 }
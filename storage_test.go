@@ -81,6 +81,23 @@ func TestStorageEveEntites(t *testing.T) {
 		assert.ElementsMatch(t, want, got)
 		assert.ElementsMatch(t, []int32{4, 5}, missing)
 	})
+	t.Run("can list entities by ID incl. stale entities", func(t *testing.T) {
+		st.MustClear()
+		createEveEntity(EveEntity{EntityID: 1})
+		createEveEntity(EveEntity{EntityID: 2})
+		createEveEntity(EveEntity{EntityID: 4, Timestamp: time.Now().Add(-1000 * time.Hour)})
+		ee, missing, err := st.ListEveEntityByID([]int32{1, 4, 5})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := make([]int32, 0)
+		for _, x := range ee {
+			got = append(got, x.ID())
+		}
+		want := []int32{1, 4}
+		assert.ElementsMatch(t, want, got)
+		assert.ElementsMatch(t, []int32{5}, missing)
+	})
 	t.Run("can list fresh entities by Name", func(t *testing.T) {
 		st.MustClear()
 		o1 := createEveEntity(EveEntity{Name: "alpha"})
@@ -133,6 +150,9 @@ func TestStorageEveTypes(t *testing.T) {
 		if o.Name == "" {
 			o.Name = fmt.Sprintf("Type #%d", o.TypeID)
 		}
+		if o.DogmaEffects == nil {
+			o.DogmaEffects = []EveTypeDogmaEffect{}
+		}
 		err := st.UpdateOrCreateEveType([]EveType{o})
 		if err != nil {
 			panic(err)
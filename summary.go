@@ -0,0 +1,93 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+)
+
+const nameNoAlliance = "(no alliance)"
+
+type summaryRow struct {
+	count int
+	id    int32
+	name  string
+	extra []any
+}
+
+// buildCharacterSummary returns tables which show how many of the given characters
+// belong to each alliance and corporation, sorted by count in descending order.
+// Characters in NPC corporations are shown in a separate table.
+func (a App) buildCharacterSummary(ids []int32) ([]result, error) {
+	characters, err := a.fetchCharacters(ids)
+	if err != nil {
+		return nil, err
+	}
+	corporationCounts := make(map[int32]int)
+	var allianceIDs, corporationIDs []int32
+	for _, o := range characters {
+		corporationCounts[o.CorporationID]++
+		if o.AllianceID != 0 {
+			allianceIDs = append(allianceIDs, o.AllianceID)
+		}
+		corporationIDs = append(corporationIDs, o.CorporationID)
+	}
+	alliances, err := a.fetchAlliance(allianceIDs)
+	if err != nil {
+		return nil, err
+	}
+	allianceLookup := makeLookupMap(alliances)
+	corporations, err := a.fetchCorporations(corporationIDs)
+	if err != nil {
+		return nil, err
+	}
+	corporationLookup := makeLookupMap(corporations)
+	allianceCounts := make(map[int32]int)
+	for _, o := range characters {
+		if corporationLookup[o.CorporationID].IsNPC() {
+			continue // already counted in the NPC corporations
+		}
+		allianceCounts[o.AllianceID]++
+	}
+
+	var allianceRows []summaryRow
+	for id, n := range allianceCounts {
+		if id == 0 {
+			allianceRows = append(allianceRows, summaryRow{count: n, name: nameNoAlliance, extra: []any{""}})
+			continue
+		}
+		o := allianceLookup[id]
+		allianceRows = append(allianceRows, summaryRow{count: n, id: id, name: o.Name, extra: []any{o.Ticker}})
+	}
+	var corporationRows, npcRows []summaryRow
+	for id, n := range corporationCounts {
+		o := corporationLookup[id]
+		if o.IsNPC() {
+			npcRows = append(npcRows, summaryRow{count: n, id: id, name: o.Name})
+			continue
+		}
+		allianceName := allianceLookup[o.AllianceID].Name
+		corporationRows = append(corporationRows, summaryRow{count: n, id: id, name: o.Name, extra: []any{o.Ticker, allianceName}})
+	}
+	results := []result{
+		{"Alliances", makeSummaryTable(a, []string{"Count", "Name", "Ticker", "ID"}, allianceRows)},
+		{"Corporations", makeSummaryTable(a, []string{"Count", "Name", "Ticker", "AllianceName", "ID"}, corporationRows)},
+	}
+	if len(npcRows) > 0 {
+		results = append(results, result{"NPC Corporations", makeSummaryTable(a, []string{"Count", "Name", "ID"}, npcRows)})
+	}
+	return results, nil
+}
+
+// makeSummaryTable returns a table for summary rows sorted by count in descending order
+// and then by name.
+func makeSummaryTable(a App, headers []string, data []summaryRow) *table {
+	slices.SortFunc(data, func(x, y summaryRow) int {
+		return cmp.Or(cmp.Compare(y.count, x.count), cmp.Compare(x.name, y.name))
+	})
+	t := &table{headers: headers}
+	for _, r := range data {
+		values := slices.Concat([]any{r.count, r.name}, r.extra, []any{idOrEmpty(r.id)})
+		t.rows = append(t.rows, tableRow{values: values, color: a.highlightColor(r.id)})
+	}
+	return t
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_Summary(t *testing.T) {
+	entities := []entity{
+		{1001, "Alpha", "character"},
+		{1002, "Bravo", "character"},
+		{1003, "Charlie", "character"},
+		{1004, "Delta", "character"},
+		{1000035, "Caldari Navy", "corporation"},
+		{98000001, "Ropers Inc", "corporation"},
+		{98000002, "Lonely Corp", "corporation"},
+		{99000001, "RAPID HEAVY ROPERS", "alliance"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint(entities),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/characters/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			1001: {"name": "Alpha", "corporation_id": 98000001, "alliance_id": 99000001},
+			1002: {"name": "Bravo", "corporation_id": 98000001, "alliance_id": 99000001},
+			1003: {"name": "Charlie", "corporation_id": 98000002},
+			1004: {"name": "Delta", "corporation_id": 1000035},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/corporations/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			1000035:  {"name": "Caldari Navy", "ticker": "CN", "member_count": 160},
+			98000001: {"name": "Ropers Inc", "ticker": "ROPE1", "member_count": 20, "alliance_id": 99000001},
+			98000002: {"name": "Lonely Corp", "ticker": "LONE", "member_count": 1},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/alliances/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			99000001: {"name": "RAPID HEAVY ROPERS", "ticker": "ROPE"},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("can show characters grouped by alliance and corporation", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Summary = true
+		err := a.Run([]string{"1001", "1002", "1003", "1004", "99000001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Summary of 4 characters")
+		assert.Regexp(t, regexp.MustCompile(`2\s+│ RAPID HEAVY ROPERS\s+│ ROPE`), got)
+		assert.Regexp(t, regexp.MustCompile(`1\s+│ \(no alliance\)`), got)
+		assert.Regexp(t, regexp.MustCompile(`2\s+│ Ropers Inc\s+│ ROPE1\s+│ RAPID HEAVY ROPERS`), got)
+		assert.Contains(t, got, "NPC Corporations:")
+		assert.Regexp(t, regexp.MustCompile(`1\s+│ Caldari Navy`), got)
+	})
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+// table represents a result in tabular form.
+// It is rendered as table or in detail mode as one card per row.
+type table struct {
+	headers []string
+	rows    []tableRow
+}
+
+type tableRow struct {
+	values []any
+	color  string // color for highlighting this row. Empty when not highlighted.
+}
+
+// makeSortedTable returns a table for objs sorted by ID.
+func makeSortedTable[T EveObject](a App, headers []string, objs []T, makeRow func(T) []any) *table {
+	slices.SortFunc(objs, func(a, b T) int {
+		return cmp.Compare(a.ID(), b.ID())
+	})
+	t := &table{headers: headers}
+	for _, o := range objs {
+		ids := []int32{o.ID()}
+		if x, ok := any(o).(affiliated); ok {
+			ids = append(ids, x.AffiliationIDs()...)
+		}
+		t.rows = append(t.rows, tableRow{values: makeRow(o), color: a.highlightColor(ids...)})
+	}
+	return t
+}
+
+// renderTable renders a table to the output.
+// In detail mode it renders a card for each row instead, which shows the values vertically.
+func (a App) renderTable(t *table) error {
+	sortRows(t, a.Sort)
+	indexes := columnIndexes(t.headers, a.Columns)
+	makeValues := func(r tableRow) []any {
+		values := make([]any, 0, len(indexes))
+		for _, i := range indexes {
+			v := r.values[i]
+			if r.color != "" {
+				v = colorize(fmt.Sprint(v), r.color)
+			}
+			values = append(values, v)
+		}
+		return values
+	}
+	if a.Detail {
+		for _, r := range t.rows {
+			values := makeValues(r)
+			fields := make([][]any, 0, len(values))
+			for i, idx := range indexes {
+				fields = append(fields, []any{strings.Join(tw.SplitCamelCase(t.headers[idx]), " "), values[i]})
+			}
+			if err := newTableWriter(a, nil, fields).Render(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	headers := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		headers = append(headers, t.headers[i])
+	}
+	rows := make([][]any, 0, len(t.rows))
+	for _, r := range t.rows {
+		rows = append(rows, makeValues(r))
+	}
+	return newTableWriter(a, headers, rows).Render()
+}
+
+func newTableWriter(a App, headers []string, rows [][]any) *tablewriter.Table {
+	t := tablewriter.NewTable(a.out,
+		tablewriter.WithRenderer(renderer.NewBlueprint(tw.Rendition{
+			Settings: tw.Settings{Separators: tw.Separators{BetweenRows: tw.On}},
+		})),
+		tablewriter.WithConfig(tablewriter.Config{
+			MaxWidth: a.MaxWidth,
+			Row: tw.CellConfig{
+				Formatting: tw.CellFormatting{AutoWrap: tw.WrapNormal},
+				Alignment:  tw.CellAlignment{Global: tw.AlignLeft}, // Left-align rows
+			},
+		}),
+	)
+	if len(headers) > 0 {
+		t.Header(headers)
+	}
+	t.Bulk(rows)
+	return t
+}
+
+// normalizeColumn returns a column name in a normalized form, e.g. "Corporation Name" becomes "corporationname".
+func normalizeColumn(s string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(s))
+}
+
+// columnIndexes returns the indexes of the requested columns in headers in the requested order.
+// Columns not found in headers are skipped.
+// Returns the indexes of all headers when no column is requested or none is found.
+func columnIndexes(headers []string, columns []string) []int {
+	lookup := make(map[string]int)
+	for i, h := range headers {
+		lookup[normalizeColumn(h)] = i
+	}
+	var indexes []int
+	for _, c := range columns {
+		i, ok := lookup[normalizeColumn(c)]
+		if ok {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) == 0 {
+		for i := range headers {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// sortRows sorts the rows of a table by the column given in the sort key.
+// The sort order is descending when the key starts with "-".
+// The sort is stable, so rows with equal values keep their original order, e.g. by ID.
+// Empty values, e.g. missing prices, are always sorted last.
+// Rows are not sorted when the table does not have that column.
+func sortRows(t *table, key string) {
+	if key == "" {
+		return
+	}
+	column, descending := strings.CutPrefix(key, "-")
+	idx := slices.IndexFunc(t.headers, func(h string) bool {
+		return normalizeColumn(h) == normalizeColumn(column)
+	})
+	if idx == -1 {
+		return
+	}
+	slices.SortStableFunc(t.rows, func(x, y tableRow) int {
+		xEmpty, yEmpty := x.values[idx] == "", y.values[idx] == ""
+		if xEmpty || yEmpty {
+			return cmp.Compare(boolToInt(xEmpty), boolToInt(yEmpty))
+		}
+		c := compareValues(x.values[idx], y.values[idx])
+		if descending {
+			return -c
+		}
+		return c
+	})
+}
+
+// compareValues compares two table values.
+// Numbers are compared numerically, also when given as strings incl. percentages and thousands separators.
+// Strings are compared case-insensitive and all other values by their string representation.
+func compareValues(x, y any) int {
+	toNumber := func(v any) (float64, bool) {
+		switch v := v.(type) {
+		case int:
+			return float64(v), true
+		case int32:
+			return float64(v), true
+		case int64:
+			return float64(v), true
+		case float32:
+			return float64(v), true
+		case float64:
+			return v, true
+		case age:
+			return float64(v), true
+		case string:
+			f, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSuffix(v, "%"), ",", ""), 64)
+			return f, err == nil
+		}
+		return 0, false
+	}
+	xn, ok1 := toNumber(x)
+	yn, ok2 := toNumber(y)
+	if ok1 && ok2 {
+		return cmp.Compare(xn, yn)
+	}
+	if xt, ok := x.(time.Time); ok {
+		if yt, ok := y.(time.Time); ok {
+			return xt.Compare(yt)
+		}
+	}
+	return cmp.Compare(strings.ToLower(fmt.Sprint(x)), strings.ToLower(fmt.Sprint(y)))
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// validateColumns reports an error when one of the requested columns is not found in any of the tables.
+func validateColumns(columns []string, tables []*table) error {
+	valid := make(map[string]bool)
+	for _, t := range tables {
+		for _, h := range t.headers {
+			valid[normalizeColumn(h)] = true
+		}
+	}
+	for _, c := range columns {
+		if !valid[normalizeColumn(c)] {
+			var v []string
+			for k := range valid {
+				v = append(v, k)
+			}
+			slices.Sort(v)
+			return fmt.Errorf("unknown column: %s. Valid columns are: %s", c, strings.Join(v, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColumnIndexes(t *testing.T) {
+	headers := []string{"ID", "Name", "CorporationID", "CorporationName"}
+	cases := []struct {
+		name    string
+		columns []string
+		want    []int
+	}{
+		{"all columns when none requested", nil, []int{0, 1, 2, 3}},
+		{"requested columns in requested order", []string{"corporationname", "id"}, []int{3, 0}},
+		{"normalizes column names", []string{"Corporation_Name", "corporation-id"}, []int{3, 2}},
+		{"skips unknown columns", []string{"name", "ticker"}, []int{1}},
+		{"all columns when none found", []string{"ticker"}, []int{0, 1, 2, 3}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := columnIndexes(headers, tc.columns)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateColumns(t *testing.T) {
+	tables := []*table{
+		{headers: []string{"ID", "Name"}},
+		{headers: []string{"ID", "Ticker"}},
+	}
+	t.Run("should accept columns found in any table", func(t *testing.T) {
+		err := validateColumns([]string{"name", "ticker"}, tables)
+		assert.NoError(t, err)
+	})
+	t.Run("should report unknown columns", func(t *testing.T) {
+		err := validateColumns([]string{"name", "members"}, tables)
+		assert.ErrorContains(t, err, "members")
+	})
+}
+
+func TestApp_renderTable(t *testing.T) {
+	x := &table{
+		headers: []string{"ID", "Name", "Ticker"},
+		rows: []tableRow{
+			{values: []any{1, "Alpha", "ALPHA"}},
+			{values: []any{2, "Bravo", "BRAVO"}, color: colorRed},
+		},
+	}
+	t.Run("can render selected columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		a := NewApp(nil, nil, &buf)
+		a.Columns = []string{"ticker", "name"}
+		err := a.renderTable(x)
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `TICKER\s+│\s+NAME`, got)
+		assert.Regexp(t, `ALPHA\s+│ Alpha`, got)
+		assert.Contains(t, got, colorize("Bravo", colorRed))
+		assert.NotContains(t, got, "ID")
+	})
+	t.Run("can render selected columns as cards", func(t *testing.T) {
+		var buf bytes.Buffer
+		a := NewApp(nil, nil, &buf)
+		a.Columns = []string{"name"}
+		a.Detail = true
+		err := a.renderTable(x)
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Regexp(t, `Name\s+│ Alpha`, got)
+		assert.NotContains(t, got, "Ticker")
+	})
+}
+
+func TestSortRows(t *testing.T) {
+	makeTable := func() *table {
+		return &table{
+			headers: []string{"ID", "Name", "Members", "AllianceID", "Price"},
+			rows: []tableRow{
+				{values: []any{int32(1), "bravo", int32(20), "99000002", "1,200.00"}},
+				{values: []any{int32(2), "Alpha", int32(5), "", "15.00"}},
+				{values: []any{int32(3), "charlie", int32(20), "100", "950.50"}},
+			},
+		}
+	}
+	ids := func(t *table) []any {
+		var got []any
+		for _, r := range t.rows {
+			got = append(got, r.values[0])
+		}
+		return got
+	}
+	cases := []struct {
+		key  string
+		want []any
+	}{
+		{"", []any{int32(1), int32(2), int32(3)}},
+		{"name", []any{int32(2), int32(1), int32(3)}},
+		{"-name", []any{int32(3), int32(1), int32(2)}},
+		{"members", []any{int32(2), int32(1), int32(3)}},
+		{"-members", []any{int32(1), int32(3), int32(2)}},
+		{"alliance_id", []any{int32(3), int32(1), int32(2)}},
+		{"-alliance_id", []any{int32(1), int32(3), int32(2)}},
+		{"ticker", []any{int32(1), int32(2), int32(3)}},
+		{"price", []any{int32(2), int32(3), int32(1)}},
+		{"-price", []any{int32(1), int32(3), int32(2)}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.key, func(t *testing.T) {
+			x := makeTable()
+			sortRows(x, tc.key)
+			assert.Equal(t, tc.want, ids(x))
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import "strings"
+
+// treeNode represents a node with its children in a tree.
+type treeNode struct {
+	name     string
+	children []treeNode
+}
+
+// renderTree returns a tree as text with one line per node.
+// Root nodes are rendered without connectors and children are indented below their parent.
+func renderTree(nodes []treeNode) string {
+	var sb strings.Builder
+	var render func(nodes []treeNode, prefix string)
+	render = func(nodes []treeNode, prefix string) {
+		for i, n := range nodes {
+			connector, indent := "├─ ", "│  "
+			if i == len(nodes)-1 {
+				connector, indent = "└─ ", "   "
+			}
+			sb.WriteString(prefix + connector + n.name + "\n")
+			render(n.children, prefix+indent)
+		}
+	}
+	for _, n := range nodes {
+		sb.WriteString(n.name + "\n")
+		render(n.children, "")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTree(t *testing.T) {
+	got := renderTree([]treeNode{
+		{name: "Star"},
+		{name: "Planets", children: []treeNode{
+			{name: "Planet I", children: []treeNode{{name: "Moon 1"}, {name: "Moon 2"}}},
+			{name: "Planet II", children: []treeNode{{name: "Belt 1"}}},
+		}},
+	})
+	want := `Star
+Planets
+├─ Planet I
+│  ├─ Moon 1
+│  └─ Moon 2
+└─ Planet II
+   └─ Belt 1`
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// Labels which can be used instead of a numeric standing in a watchlist.
+var watchlistLabels = map[string]float64{
+	"ally":     10,
+	"blue":     10,
+	"enemy":    -10,
+	"friend":   10,
+	"friendly": 10,
+	"hostile":  -10,
+	"neutral":  0,
+	"red":      -10,
+}
+
+// WatchlistEntry is an entry in a watchlist. Value is either the ID or the name of an Eve entity.
+type WatchlistEntry struct {
+	Value    string
+	Label    string
+	Standing float64
+}
+
+// ParseWatchlist parses a watchlist and returns it's entries.
+//
+// Each line of a watchlist has the format: "<ID or name> = <standing or label>",
+// e.g. "Goonswarm Federation = -10" or "The Congregation = blue".
+// Empty lines and lines starting with # are ignored.
+func ParseWatchlist(r io.Reader) ([]WatchlistEntry, error) {
+	var entries []WatchlistEntry
+	scanner := bufio.NewScanner(r)
+	var n int
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		value, label, found := strings.Cut(line, "=")
+		value = strings.TrimSpace(value)
+		label = strings.TrimSpace(label)
+		if !found || value == "" || label == "" {
+			return nil, fmt.Errorf("watchlist line %d: expected \"<ID or name> = <standing or label>\": %s", n, line)
+		}
+		standing, err := strconv.ParseFloat(label, 64)
+		if err != nil {
+			s, ok := watchlistLabels[strings.ToLower(label)]
+			if !ok {
+				return nil, fmt.Errorf("watchlist line %d: invalid standing or label: %s", n, label)
+			}
+			standing = s
+		}
+		entries = append(entries, WatchlistEntry{Value: value, Label: label, Standing: standing})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// resolveWatchlist returns the standings of the entities in the watchlist by their ID.
+func (a App) resolveWatchlist() (map[int32]float64, error) {
+	standings := make(map[int32]float64)
+	if len(a.Watchlist) == 0 {
+		return standings, nil
+	}
+	name2Standing := make(map[string]float64)
+	var names []string
+	for _, e := range a.Watchlist {
+		id, err := strconv.ParseInt(e.Value, 10, 32)
+		if err == nil {
+			standings[int32(id)] = e.Standing
+			continue
+		}
+		if errors.Is(err, strconv.ErrRange) {
+			slog.Warn("Ignoring invalid ID in watchlist", "id", e.Value)
+			continue
+		}
+		names = append(names, e.Value)
+		name2Standing[e.Value] = e.Standing
+	}
+	entities, err := a.resolveNames(names)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entities {
+		if e.Category == CategoryInvalid {
+			slog.Warn("Ignoring unknown name in watchlist", "name", e.Name)
+			continue
+		}
+		standings[e.ID()] = name2Standing[e.Name]
+	}
+	return standings, nil
+}
+
+// highlightColor returns the color for highlighting a row by the standing
+// of the first matching ID in the watchlist.
+// It returns an empty string when there is no match or the standing is neutral.
+func (a App) highlightColor(ids ...int32) string {
+	for _, id := range ids {
+		standing, ok := a.standings[id]
+		if !ok || id == 0 {
+			continue
+		}
+		if standing < 0 {
+			return colorRed
+		} else if standing > 0 {
+			return colorBlue
+		}
+		return ""
+	}
+	return ""
+}
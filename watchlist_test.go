@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/antihax/goesi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWatchlist(t *testing.T) {
+	t.Run("can parse watchlist", func(t *testing.T) {
+		r := strings.NewReader(`
+# comment
+Goonswarm Federation = -10
+98267621 = blue
+Pandemic Horde=hostile
+Erik Kalkoken = 5.5
+`)
+		got, err := ParseWatchlist(r)
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		want := []WatchlistEntry{
+			{Value: "Goonswarm Federation", Label: "-10", Standing: -10},
+			{Value: "98267621", Label: "blue", Standing: 10},
+			{Value: "Pandemic Horde", Label: "hostile", Standing: -10},
+			{Value: "Erik Kalkoken", Label: "5.5", Standing: 5.5},
+		}
+		assert.Equal(t, want, got)
+	})
+	t.Run("should return error when standing is missing", func(t *testing.T) {
+		_, err := ParseWatchlist(strings.NewReader("Goonswarm Federation"))
+		assert.Error(t, err)
+	})
+	t.Run("should return error when label is unknown", func(t *testing.T) {
+		_, err := ParseWatchlist(strings.NewReader("Goonswarm Federation = purple"))
+		assert.Error(t, err)
+	})
+}
+
+func TestApp_Watchlist(t *testing.T) {
+	entities := []entity{
+		{1001, "Alpha", "character"},
+		{1002, "Bravo", "character"},
+		{98000001, "Ropers Inc", "corporation"},
+		{98000002, "Lonely Corp", "corporation"},
+		{99000001, "RAPID HEAVY ROPERS", "alliance"},
+	}
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/names/`,
+		makeUniverseNamesEndpoint(entities),
+	)
+	httpmock.RegisterResponder(
+		"POST",
+		`=~^https://esi\.evetech\.net/v\d+/universe/ids/`,
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"alliances": []map[string]any{{"id": 99000001, "name": "RAPID HEAVY ROPERS"}},
+		}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi\.evetech\.net/v\d+/characters/(\d+)/`,
+		makeObjectResponder(map[int64]map[string]any{
+			1001: {"name": "Alpha", "corporation_id": 98000001, "alliance_id": 99000001},
+			1002: {"name": "Bravo", "corporation_id": 98000002},
+		}),
+	)
+	st := newTestStorage(t)
+	esiClient := goesi.NewAPIClient(nil, "")
+
+	t.Run("should highlight characters through their alliance", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		a.Watchlist = []WatchlistEntry{
+			{Value: "RAPID HEAVY ROPERS", Standing: -10},
+			{Value: "98000002", Standing: 10},
+		}
+		err := a.Run([]string{"1001", "1002"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, colorize("Alpha", colorRed))
+		assert.Contains(t, got, colorize("Bravo", colorBlue))
+	})
+	t.Run("should ignore IDs out of range", func(t *testing.T) {
+		st.MustClear()
+		a := NewApp(esiClient, st, nil)
+		a.Watchlist = []WatchlistEntry{
+			{Value: "4294968297", Standing: -10}, // would wrap to 1001
+			{Value: "1002", Standing: 10},
+		}
+		got, err := a.resolveWatchlist()
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		assert.Equal(t, map[int32]float64{1002: 10}, got)
+	})
+	t.Run("should not highlight without watchlist", func(t *testing.T) {
+		st.MustClear()
+		var buf bytes.Buffer
+		a := NewApp(esiClient, st, &buf)
+		a.SpinnerDisabled = true
+		err := a.Run([]string{"1001"})
+		if !assert.NoError(t, err) {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		assert.Contains(t, got, "Alpha")
+		assert.NotContains(t, got, colorRed)
+	})
+}